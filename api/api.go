@@ -42,13 +42,19 @@ func Start(port string) {
 	r.POST("/api/auth/signin", SigninHandler)
 	r.POST("/api/auth/signup", SignupHandler)
 	r.GET("/api/match/:id", GetMatchHandler)
+	r.GET("/api/replay/:id", GetReplayHandler)
 	r.POST("/api/match", MatchHandler)
 	r.GET("/api/cards", CardsHandler)
+	r.GET("/api/protocol", ProtocolHandler)
 	r.GET("/api/deck/:id", GetDeckHandler)
 	r.GET("/api/decks", GetDecksHandler)
 	r.POST("/api/decks", CreateDeckHandler)
 	r.DELETE("/api/deck/:id", DeleteDeckHandler)
+	r.GET("/api/bots", GetBotsHandler)
+	r.POST("/api/bots", CreateBotHandler)
+	r.POST("/api/bots/:id/token", CreateBotTokenHandler)
 	r.GET("/invite/:id", InviteHandler)
+	r.GET("/metrics", MetricsHandler)
 
 	// Because Gin does not provide an easy way to handle requests where the file does not exist
 	// (NoRoute tests on specified routes, not if the file exists) we expose our webapp's folders manually..
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"duel-masters/db"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxBots is the number of bot accounts a user can create
+const maxBots = 5
+
+type createBotReqBody struct {
+	Username string `json:"username" binding:"required,alphanum,min=3,max=20"`
+}
+
+// newAPIToken returns a new session for a bot, API tokens do not expire
+func newAPIToken(c *gin.Context) (db.UserSession, error) {
+
+	token, err := uuid.NewRandom()
+
+	if err != nil {
+		return db.UserSession{}, err
+	}
+
+	return db.UserSession{
+		Token:   token.String(),
+		IP:      c.ClientIP(),
+		Expires: 0,
+	}, nil
+
+}
+
+// GetBotsHandler returns the bot accounts of the user
+func GetBotsHandler(c *gin.Context) {
+
+	user, err := db.GetUserForToken(c.GetHeader("Authorization"))
+	if err != nil {
+		c.Status(401)
+		return
+	}
+
+	bots, err := db.Users().GetBots(user.UID)
+
+	if err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
+
+	c.JSON(200, bots)
+
+}
+
+// CreateBotHandler creates a bot account owned by the user and returns its API token.
+// Bots connect to the websocket with the token like any other user
+func CreateBotHandler(c *gin.Context) {
+
+	user, err := db.GetUserForToken(c.GetHeader("Authorization"))
+	if err != nil {
+		c.Status(401)
+		return
+	}
+
+	if user.Bot {
+		c.JSON(403, bson.M{"message": "Bots can not create other bots"})
+		return
+	}
+
+	var reqBody createBotReqBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(400, bson.M{"message": "Make sure the username only consist of a-Z and 0-9 (3-20 characters long)"})
+		return
+	}
+
+	bots, err := db.Users().GetBots(user.UID)
+
+	if err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
+
+	if len(bots) >= maxBots {
+		c.JSON(403, bson.M{"message": "You can not create more bots"})
+		return
+	}
+
+	if _, err := db.Users().GetByUsername(reqBody.Username); err == nil {
+		c.JSON(400, bson.M{"message": "The username is already taken"})
+		return
+	}
+
+	session, err := newAPIToken(c)
+
+	if err != nil {
+		c.Status(500)
+		return
+	}
+
+	bot := db.User{
+		UID:         uuid.New().String(),
+		Username:    reqBody.Username,
+		Permissions: []string{},
+		Rating:      db.DefaultRating,
+		Sessions:    []db.UserSession{session},
+		Bot:         true,
+		Owner:       user.UID,
+	}
+
+	if err := db.Users().Create(bot); err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
+
+	c.JSON(200, bson.M{"bot": bot, "token": session.Token})
+
+}
+
+// CreateBotTokenHandler issues a new API token for one of the user's bots, the
+// previous tokens of the bot stop working
+func CreateBotTokenHandler(c *gin.Context) {
+
+	user, err := db.GetUserForToken(c.GetHeader("Authorization"))
+	if err != nil {
+		c.Status(401)
+		return
+	}
+
+	bot, err := db.Users().Get(c.Param("id"))
+
+	if err != nil || !bot.Bot || bot.Owner != user.UID {
+		c.Status(404)
+		return
+	}
+
+	session, err := newAPIToken(c)
+
+	if err != nil {
+		c.Status(500)
+		return
+	}
+
+	if err := db.Sessions().Replace(bot.UID, session); err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
+
+	c.JSON(200, bson.M{"bot": bot, "token": session.Token})
+
+}
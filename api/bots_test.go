@@ -0,0 +1,79 @@
+package api_test
+
+import (
+	"duel-masters/api"
+	"duel-masters/db"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type botResponse struct {
+	Bot   db.User `json:"bot"`
+	Token string  `json:"token"`
+}
+
+// request sends the request to the bot handlers and decodes the response into v
+func request(t *testing.T, r *gin.Engine, method string, path string, token string, body string, v interface{}) int {
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if v != nil && w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return w.Code
+
+}
+
+func TestCreateBotTokenRevokesPreviousToken(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	if err := db.Users().Create(db.User{UID: "owner", Username: "Owner", Sessions: []db.UserSession{{Token: "owner"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/api/bots", api.GetBotsHandler)
+	r.POST("/api/bots", api.CreateBotHandler)
+	r.POST("/api/bots/:id/token", api.CreateBotTokenHandler)
+
+	var created botResponse
+
+	if code := request(t, r, "POST", "/api/bots", "owner", `{"username": "Bot"}`, &created); code != http.StatusOK {
+		t.Fatalf("Expected the bot to be created, got status %v", code)
+	}
+
+	if code := request(t, r, "GET", "/api/bots", created.Token, "", nil); code != http.StatusOK {
+		t.Fatalf("Expected the token of the new bot to be accepted, got status %v", code)
+	}
+
+	var rotated botResponse
+
+	if code := request(t, r, "POST", "/api/bots/"+created.Bot.UID+"/token", "owner", "", &rotated); code != http.StatusOK {
+		t.Fatalf("Expected a new token to be issued, got status %v", code)
+	}
+
+	if code := request(t, r, "GET", "/api/bots", created.Token, "", nil); code != http.StatusUnauthorized {
+		t.Errorf("Expected the previous token to be rejected after issuing a new one, got status %v", code)
+	}
+
+	if code := request(t, r, "GET", "/api/bots", rotated.Token, "", nil); code != http.StatusOK {
+		t.Errorf("Expected the new token to be accepted, got status %v", code)
+	}
+
+}
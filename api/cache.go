@@ -10,13 +10,14 @@ import (
 
 // CardInfo struct is used for the card database api
 type CardInfo struct {
-	UID          string `json:"uid"`
-	Name         string `json:"name"`
-	Civilization string `json:"civilization"`
-	Family       string `json:"family"`
-	ManaCost     int    `json:"manaCost"`
-	Set          string `json:"set"`
-	Type         string `json:"type"`
+	UID           string   `json:"uid"`
+	Name          string   `json:"name"`
+	Civilization  string   `json:"civilization"`
+	Civilizations []string `json:"civilizations"`
+	Family        string   `json:"family"`
+	ManaCost      int      `json:"manaCost"`
+	Set           string   `json:"set"`
+	Type          string   `json:"type"`
 }
 
 // Register holds all the card info
@@ -35,13 +36,14 @@ func CreateCardCache() {
 			c(card)
 
 			entry := CardInfo{
-				UID:          uid,
-				Name:         card.Name,
-				Civilization: card.Civ,
-				Set:          setID,
-				Family:       card.Family,
-				ManaCost:     card.ManaCost,
-				Type:         "Creature",
+				UID:           uid,
+				Name:          card.Name,
+				Civilization:  card.Civ,
+				Civilizations: card.Civilizations(),
+				Set:           setID,
+				Family:        card.Family,
+				ManaCost:      card.ManaCost,
+				Type:          "Creature",
 			}
 
 			if entry.Family == "" {
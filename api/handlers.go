@@ -1,13 +1,15 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"duel-masters/db"
 	"duel-masters/game"
+	"duel-masters/game/bot"
+	"duel-masters/game/format"
 	"duel-masters/game/match"
 	"duel-masters/server"
 
@@ -16,7 +18,6 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -34,11 +35,9 @@ func SigninHandler(c *gin.Context) {
 		return
 	}
 
-	collection := db.Collection("users")
+	user, err := db.Users().GetByUsername(reqBody.Username)
 
-	var user db.User
-
-	if err := collection.FindOne(context.TODO(), bson.M{"username": primitive.Regex{Pattern: "^" + reqBody.Username + "$", Options: "i"}}).Decode(&user); err != nil {
+	if err != nil {
 		c.Status(401)
 		return
 	}
@@ -60,7 +59,11 @@ func SigninHandler(c *gin.Context) {
 		Expires: int(time.Now().Add(time.Second * 2592000).Unix()),
 	}
 
-	collection.UpdateOne(context.TODO(), bson.M{"uid": user.UID}, bson.M{"$push": bson.M{"sessions": session}})
+	if err := db.Sessions().Add(user.UID, session); err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
 
 	c.JSON(200, bson.M{"user": user, "token": session.Token})
 
@@ -85,14 +88,12 @@ func SignupHandler(c *gin.Context) {
 		return
 	}
 
-	collection := db.Collection("users")
-
-	if err := collection.FindOne(context.TODO(), bson.M{"username": primitive.Regex{Pattern: "^" + reqBody.Username + "$", Options: "i"}}).Decode(&db.User{}); err == nil {
+	if _, err := db.Users().GetByUsername(reqBody.Username); err == nil {
 		c.JSON(400, bson.M{"message": "The username is already taken"})
 		return
 	}
 
-	if err := collection.FindOne(context.TODO(), bson.M{"email": primitive.Regex{Pattern: "^" + reqBody.Email + "$", Options: "i"}}).Decode(&db.User{}); err == nil {
+	if _, err := db.Users().GetByEmail(reqBody.Email); err == nil {
 		c.JSON(400, bson.M{"message": "The email is already taken"})
 		return
 	}
@@ -122,14 +123,13 @@ func SignupHandler(c *gin.Context) {
 		Email:       reqBody.Email,
 		Password:    string(hash),
 		Permissions: []string{},
+		Rating:      db.DefaultRating,
 		Sessions: []db.UserSession{
 			session,
 		},
 	}
 
-	_, err = collection.InsertOne(context.TODO(), user)
-
-	if err != nil {
+	if err := db.Users().Create(user); err != nil {
 		c.Status(500)
 		return
 	}
@@ -141,6 +141,18 @@ func SignupHandler(c *gin.Context) {
 type matchReqBody struct {
 	Name       string `json:"name" binding:"required,min=3,max=100"`
 	Visibility string `json:"visibility" binding:"required"`
+	TurnTime   int    `json:"turnTime" binding:"min=0,max=600"`
+	TotalTime  int    `json:"totalTime" binding:"min=0,max=7200"`
+	Computer   bool   `json:"computer"`
+	Mode       string `json:"mode"`
+}
+
+// seriesGames maps the match modes to the number of games that are played
+var seriesGames = map[string]int{
+	"":       1,
+	"single": 1,
+	"bo3":    3,
+	"bo5":    5,
 }
 
 // MatchHandler handles creation of new mathes
@@ -158,6 +170,17 @@ func MatchHandler(c *gin.Context) {
 		return
 	}
 
+	games, ok := seriesGames[reqBody.Mode]
+	if !ok {
+		c.Status(400)
+		return
+	}
+
+	if games > 1 && reqBody.Computer {
+		c.JSON(400, bson.M{"message": "Series can only be played against other players"})
+		return
+	}
+
 	visible := true
 	if reqBody.Visibility == "private" {
 		visible = false
@@ -165,6 +188,29 @@ func MatchHandler(c *gin.Context) {
 
 	m := match.New(reqBody.Name, user.UID, visible)
 
+	m.Do(func() {
+
+		m.SetClock(match.Clock{
+			TurnTime:  time.Duration(reqBody.TurnTime) * time.Second,
+			TotalTime: time.Duration(reqBody.TotalTime) * time.Second,
+		})
+
+		if games > 1 {
+			m.SetSeries(match.NewSeries(games))
+		}
+
+		if reqBody.Computer {
+			_, err = m.AddComputerOpponent(bot.UID, bot.Username, bot.New())
+		}
+
+	})
+
+	if err != nil {
+		logrus.Error(err)
+		c.Status(500)
+		return
+	}
+
 	c.JSON(200, m)
 
 }
@@ -186,6 +232,17 @@ func WS(c *gin.Context) {
 
 		hub = game.GetLobby()
 
+	} else if strings.HasPrefix(hubID, "replay-") {
+
+		r, err := game.LoadReplay(strings.TrimPrefix(hubID, "replay-"))
+
+		if err != nil {
+			c.Status(404)
+			return
+		}
+
+		hub = r
+
 	} else {
 
 		m, err := match.Find(hubID)
@@ -218,29 +275,24 @@ func CardsHandler(c *gin.Context) {
 	c.JSON(200, GetCache())
 }
 
+// ProtocolHandler returns the JSON Schema of the websocket protocol
+func ProtocolHandler(c *gin.Context) {
+	c.JSON(200, server.Schema())
+}
+
 // GetDeckHandler returns a single deck, if public
 func GetDeckHandler(c *gin.Context) {
 
 	deckUID := c.Param("id")
 
-	var deck db.Deck
+	deck, err := db.Decks().Get(deckUID)
 
-	err := db.Collection("decks").FindOne(
-		context.Background(),
-		bson.M{"uid": deckUID, "public": true},
-	).Decode(&deck)
-
-	if err != nil {
+	if err != nil || !deck.Public {
 		c.Status(404)
 		return
 	}
 
-	var user db.User
-
-	err = db.Collection("users").FindOne(
-		context.Background(),
-		bson.M{"uid": deck.Owner},
-	).Decode(&user)
+	user, err := db.Users().Get(deck.Owner)
 
 	if err != nil {
 		c.Status(404)
@@ -262,11 +314,7 @@ func GetDecksHandler(c *gin.Context) {
 		return
 	}
 
-	collection := db.Collection("decks")
-
-	cur, err := collection.Find(context.TODO(), bson.M{
-		"owner": user.UID,
-	})
+	decks, err := db.Decks().GetByOwner(user.UID)
 
 	if err != nil {
 		logrus.Error(err)
@@ -274,22 +322,6 @@ func GetDecksHandler(c *gin.Context) {
 		return
 	}
 
-	defer cur.Close(context.TODO())
-
-	decks := make([]db.Deck, 0)
-
-	for cur.Next(context.TODO()) {
-
-		var deck db.Deck
-
-		if err := cur.Decode(&deck); err != nil {
-			continue
-		}
-
-		decks = append(decks, deck)
-
-	}
-
 	c.JSON(200, decks)
 
 }
@@ -316,25 +348,16 @@ func CreateDeckHandler(c *gin.Context) {
 		return
 	}
 
-	if len(reqBody.Cards) < 40 || len(reqBody.Cards) > 50 {
-		c.Status(400)
+	if err := format.Standard.Validate(reqBody.Cards); err != nil {
+		c.JSON(400, deckError(err))
 		return
 	}
 
-	for _, cuid := range reqBody.Cards {
-		if !CacheHas(cuid) {
-			c.Status(400)
-			return
-		}
-	}
-
-	collection := db.Collection("decks")
-
 	if len(reqBody.UID) < 1 {
 
 		// New deck
 
-		decksCount, err := collection.CountDocuments(context.TODO(), bson.M{"owner": user.UID})
+		decksCount, err := db.Decks().Count(user.UID)
 
 		if err != nil {
 			logrus.Error(err)
@@ -356,9 +379,7 @@ func CreateDeckHandler(c *gin.Context) {
 			Cards:    reqBody.Cards,
 		}
 
-		_, err = collection.InsertOne(context.TODO(), deck)
-
-		if err != nil {
+		if err := db.Decks().Create(deck); err != nil {
 			c.Status(500)
 			return
 		}
@@ -367,11 +388,13 @@ func CreateDeckHandler(c *gin.Context) {
 
 		// Edit deck
 
-		_, err := collection.UpdateOne(
-			context.TODO(),
-			bson.M{"uid": reqBody.UID, "owner": user.UID},
-			bson.M{"$set": bson.M{"name": reqBody.Name, "public": reqBody.Public, "cards": reqBody.Cards}},
-		)
+		err := db.Decks().Update(db.Deck{
+			UID:    reqBody.UID,
+			Owner:  user.UID,
+			Name:   reqBody.Name,
+			Public: reqBody.Public,
+			Cards:  reqBody.Cards,
+		})
 
 		if err != nil {
 			logrus.Error(err)
@@ -385,6 +408,19 @@ func CreateDeckHandler(c *gin.Context) {
 
 }
 
+// deckError returns the response body for a deck that failed validation
+func deckError(err error) bson.M {
+
+	result := bson.M{"message": err.Error()}
+
+	if e, ok := err.(*format.Error); ok {
+		result["violations"] = e.Violations
+	}
+
+	return result
+
+}
+
 // DeleteDeckHandler deletes the specified deck
 func DeleteDeckHandler(c *gin.Context) {
 
@@ -396,17 +432,7 @@ func DeleteDeckHandler(c *gin.Context) {
 
 	deckUID := c.Param("id")
 
-	result, err := db.Collection("decks").DeleteOne(
-		context.Background(),
-		bson.M{"uid": deckUID, "owner": user.UID},
-	)
-
-	if err != nil {
-		c.Status(401)
-		return
-	}
-
-	if result.DeletedCount < 1 {
+	if err := db.Decks().Delete(deckUID, user.UID); err != nil {
 		c.Status(401)
 		return
 	}
@@ -428,6 +454,32 @@ func GetMatchHandler(c *gin.Context) {
 
 }
 
+// GetReplayHandler returns the recorded event log of a finished match to signed in users
+func GetReplayHandler(c *gin.Context) {
+
+	if _, err := db.GetUserForToken(c.GetHeader("Authorization")); err != nil {
+		c.Status(401)
+		return
+	}
+
+	// the event log contains the hidden cards of both players, so it is only
+	// published once the match is over
+	if _, err := match.Find(c.Param("id")); err == nil {
+		c.Status(404)
+		return
+	}
+
+	replay, err := db.GetReplay(c.Param("id"))
+
+	if err != nil {
+		c.Status(404)
+		return
+	}
+
+	c.JSON(200, replay)
+
+}
+
 // InviteHandler handles duel invitations
 func InviteHandler(c *gin.Context) {
 
@@ -469,7 +521,7 @@ func InviteHandler(c *gin.Context) {
 				<script>if(!navigator.userAgent.includes("discord")) { window.location.replace("/overview"); }</script>
 			</body>
 		</html>
-		`, match.Player1.Socket.User.Username, match.Player2.Socket.User.Username)
+		`, match.Player1.Username, match.Player2.Username)
 	} else if match.Player1 != nil {
 		res = fmt.Sprintf(`
 		<!DOCTYPE html>
@@ -486,7 +538,7 @@ func InviteHandler(c *gin.Context) {
 				<script>if(!navigator.userAgent.includes("discord")) { window.location.replace("/duel/%s"); }</script>
 			</body>
 		</html>
-		`, match.Player1.Socket.User.Username, c.Param("id"))
+		`, match.Player1.Username, c.Param("id"))
 	} else {
 		res = `
 		<!DOCTYPE html>
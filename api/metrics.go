@@ -0,0 +1,39 @@
+package api
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"strings"
+
+	"duel-masters/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsToken is the token that has to be sent to read the metrics.
+// The metrics are not served if it is empty
+var MetricsToken = ""
+
+// MetricsHandler writes the metrics of the server in the Prometheus text format.
+// The token is sent as a bearer token in the Authorization header
+func MetricsHandler(c *gin.Context) {
+
+	if MetricsToken == "" {
+		c.Status(404)
+		return
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(MetricsToken)) != 1 {
+		c.Status(401)
+		return
+	}
+
+	var b bytes.Buffer
+
+	metrics.Write(&b)
+
+	c.Data(200, "text/plain; version=0.0.4; charset=utf-8", b.Bytes())
+
+}
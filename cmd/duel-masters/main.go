@@ -27,22 +27,52 @@ func main() {
 
 	go checkForAutoRestart()
 
-	for _, set := range cards.Sets {
-		for uid, ctor := range *set {
-			match.AddCard(uid, ctor)
-		}
-	}
+	setDisconnectGrace()
+
+	match.CheckInvariants = os.Getenv("check_invariants") == "true"
+
+	api.MetricsToken = os.Getenv("metrics_token")
+
+	cards.RegisterAll()
 
 	go game.GetLobby().StartTicker()
 
 	api.CreateCardCache()
 
-	db.Connect(os.Getenv("mongo_uri"), os.Getenv("mongo_name"))
+	setStorage()
 
 	api.Start(os.Getenv("port"))
 
 }
 
+func setStorage() {
+
+	if os.Getenv("storage") == "memory" {
+		logrus.Warn("Using in-memory storage, all data is lost when the server is stopped")
+		db.Use(db.NewMemoryStorage())
+		return
+	}
+
+	db.Connect(os.Getenv("mongo_uri"), os.Getenv("mongo_name"))
+
+}
+
+func setDisconnectGrace() {
+
+	if os.Getenv("disconnect_grace") == "" {
+		return
+	}
+
+	n, err := strconv.Atoi(os.Getenv("disconnect_grace"))
+
+	if err != nil {
+		panic(err)
+	}
+
+	match.DisconnectGrace = time.Second * time.Duration(n)
+
+}
+
 func checkForAutoRestart() {
 
 	if os.Getenv("restart_after") == "" {
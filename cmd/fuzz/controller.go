@@ -0,0 +1,399 @@
+package main
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// maxMoves is the number of commands the fuzzer makes in a turn before ending it
+const maxMoves = 30
+
+// fuzzer is a LocalController that makes a random legal move until it decides to end its
+// turn, and answers every prompt with a random selection
+type fuzzer struct {
+	match  *match.Match
+	player *match.PlayerReference
+
+	rng      *rand.Rand
+	prompt   interface{}
+	attempts int
+	lastTurn int
+	warning  string
+	mutex    sync.Mutex
+
+	// progress is increased for every message and move, so the game can tell when the
+	// match stopped moving
+	progress int64
+
+	// report is called with everything that went wrong
+	report func(kind string, message string, detail string)
+
+	done chan struct{}
+	once sync.Once
+}
+
+func newFuzzer(seed int64, report func(string, string, string)) *fuzzer {
+
+	return &fuzzer{
+		rng:    rand.New(rand.NewSource(seed)),
+		report: report,
+		done:   make(chan struct{}),
+	}
+
+}
+
+// Attach stores the match and player the fuzzer is playing for
+func (f *fuzzer) Attach(m *match.Match, p *match.PlayerReference) {
+	f.match = m
+	f.player = p
+}
+
+// Send answers action prompts with a random selection
+func (f *fuzzer) Send(msg interface{}) {
+
+	atomic.AddInt64(&f.progress, 1)
+
+	switch msg := msg.(type) {
+
+	case *server.ActionMessage, *server.MultipartActionMessage:
+		f.mutex.Lock()
+		f.prompt = msg
+		f.attempts = 0
+		f.mutex.Unlock()
+		f.respond()
+
+	case server.ActionWarningMessage:
+		f.mutex.Lock()
+		f.attempts++
+		f.mutex.Unlock()
+		f.respond()
+
+	case server.WarningMessage:
+		if msg.Header == "warn" {
+			f.mutex.Lock()
+			f.warning = msg.Message
+			f.mutex.Unlock()
+		}
+
+	case server.Message:
+		if msg.Header == "close_action" {
+			f.mutex.Lock()
+			f.prompt = nil
+			f.mutex.Unlock()
+		}
+
+	}
+
+}
+
+// Close is called when the match is disposed
+func (f *fuzzer) Close() {
+	f.once.Do(func() { close(f.done) })
+}
+
+// Done returns a channel that is closed when the match is disposed
+func (f *fuzzer) Done() <-chan struct{} {
+	return f.done
+}
+
+// Progress returns a number that changes whenever the fuzzer receives a message or makes a move
+func (f *fuzzer) Progress() int64 {
+	return atomic.LoadInt64(&f.progress)
+}
+
+// Turn returns the number of the last turn the fuzzer played
+func (f *fuzzer) Turn() int {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.lastTurn
+
+}
+
+// Warning returns the last warning the fuzzer received
+func (f *fuzzer) Warning() string {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.warning
+
+}
+
+// Prompt returns the text of the prompt the fuzzer is answering, or an empty string
+func (f *fuzzer) Prompt() string {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch msg := f.prompt.(type) {
+	case *server.ActionMessage:
+		return msg.Text
+	case *server.MultipartActionMessage:
+		return msg.Text
+	}
+
+	return ""
+
+}
+
+// TakeTurn makes random legal moves until the fuzzer decides to end the turn
+func (f *fuzzer) TakeTurn() {
+
+	// The turn is played again if it could not be ended, e.g. because a creature has to attack.
+	// The fuzzer attacks whenever it can then, so that only turns that can't be ended are reported
+	retry := false
+
+	f.match.Do(func() {
+
+		turn := f.match.Turns()
+
+		f.mutex.Lock()
+		retry = turn == f.lastTurn
+		f.lastTurn = turn
+		f.mutex.Unlock()
+
+	})
+
+	for i := 0; i < maxMoves; i++ {
+
+		more := false
+
+		f.match.Do(func() { more = f.move(retry) })
+
+		if !more {
+			return
+		}
+
+	}
+
+}
+
+// move makes a random legal move or ends the turn, and returns false if the turn is over.
+// Only attacks are made when the turn is retried
+func (f *fuzzer) move(retry bool) bool {
+
+	if f.match.Ended() {
+		return false
+	}
+
+	legal := f.match.LegalActions(f.player.Player)
+
+	names := make([]string, 0)
+	moves := make([]func(), 0)
+
+	for _, c := range legal.Charge {
+		id := c.ID
+		names = append(names, fmt.Sprintf("charge %s", c.Name))
+		moves = append(moves, func() { f.match.ChargeMana(f.player, id) })
+	}
+
+	for _, c := range legal.Play {
+		id := c.ID
+		names = append(names, fmt.Sprintf("play %s", c.Name))
+		moves = append(moves, func() { f.match.PlayCard(f.player, id) })
+	}
+
+	for _, attack := range legal.Attacks {
+
+		id := attack.Card.ID
+
+		if attack.Player {
+			names = append(names, fmt.Sprintf("attack the player with %s", attack.Card.Name))
+			moves = append(moves, func() { f.match.AttackPlayer(f.player, id) })
+		}
+
+		if len(attack.Creatures) > 0 {
+			names = append(names, fmt.Sprintf("attack a creature with %s", attack.Card.Name))
+			moves = append(moves, func() { f.match.AttackCreature(f.player, id) })
+		}
+
+	}
+
+	attacks := len(moves) - len(legal.Charge) - len(legal.Play)
+
+	if retry && attacks < 1 {
+		return false
+	}
+
+	// ending the turn is one of the options, so turns are neither always short nor always long
+	n := f.intn(len(moves) + 1)
+
+	if retry {
+		n = len(moves) - attacks + f.intn(attacks)
+	}
+
+	// the turn is ended here rather than by the match, so a panic in the
+	// end of turn triggers is reported with its stack
+	if n >= len(moves) {
+		f.safely("end the turn", f.match.EndTurn)
+		return false
+	}
+
+	atomic.AddInt64(&f.progress, 1)
+
+	return f.safely(names[n], moves[n])
+
+}
+
+// safely makes the move and reports it if it panics
+func (f *fuzzer) safely(name string, move func()) (ok bool) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			f.report("panic", fmt.Sprintf("%s panicked trying to %s: %v", f.player.Username, name, r), string(debug.Stack()))
+			ok = false
+		}
+	}()
+
+	move()
+
+	return true
+
+}
+
+// respond answers the current prompt with a random selection. If the match rejected the
+// previous selection the minimum selection and then cancelling are tried, the prompt is
+// left unanswered after that and reported once the match stalls
+func (f *fuzzer) respond() {
+
+	f.mutex.Lock()
+	prompt := f.prompt
+	attempts := f.attempts
+	f.mutex.Unlock()
+
+	if prompt == nil {
+		return
+	}
+
+	ids, min, max, cancellable, suggested := details(prompt)
+
+	var action match.PlayerAction
+
+	switch {
+
+	case attempts < 3:
+		action = f.random(ids, min, max, cancellable, suggested)
+
+	case attempts == 3 && len(suggested) > 0:
+		action = match.PlayerAction{Cards: suggested}
+
+	case attempts <= 4:
+		action = match.PlayerAction{Cards: first(ids, min)}
+
+	case attempts == 5:
+		action = match.PlayerAction{Cancel: true}
+
+	default:
+		return
+
+	}
+
+	// Send is called from the loop of the match, which handles the response
+	// once it is done sending the prompt
+	go f.player.Player.Respond(action)
+
+}
+
+// random returns a random selection of the cards of a prompt, mana payments use the
+// suggested payment most of the time so that cards are actually played
+func (f *fuzzer) random(ids []string, min int, max int, cancellable bool, suggested []string) match.PlayerAction {
+
+	if cancellable && f.intn(10) == 0 {
+		return match.PlayerAction{Cancel: true}
+	}
+
+	if len(suggested) > 0 && f.intn(4) > 0 {
+		return match.PlayerAction{Cards: suggested}
+	}
+
+	if max > len(ids) {
+		max = len(ids)
+	}
+
+	if min > max {
+		min = max
+	}
+
+	if min < 0 {
+		min = 0
+	}
+
+	n := min + f.intn(max-min+1)
+
+	f.mutex.Lock()
+	order := f.rng.Perm(len(ids))
+	f.mutex.Unlock()
+
+	result := make([]string, 0)
+
+	for _, i := range order[:n] {
+		result = append(result, ids[i])
+	}
+
+	return match.PlayerAction{Cards: result}
+
+}
+
+// intn returns a random number in [0, n)
+func (f *fuzzer) intn(n int) int {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.rng.Intn(n)
+
+}
+
+// details returns the card ids in the order they were sent and the requirements of the prompt
+func details(prompt interface{}) ([]string, int, int, bool, []string) {
+
+	ids := make([]string, 0)
+
+	switch msg := prompt.(type) {
+
+	case *server.ActionMessage:
+		for _, c := range msg.Cards {
+			ids = append(ids, c.CardID)
+		}
+		return ids, msg.MinSelections, msg.MaxSelections, msg.Cancellable, msg.Suggested
+
+	case *server.MultipartActionMessage:
+		keys := make([]string, 0)
+		for key := range msg.Cards {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, c := range msg.Cards[key] {
+				ids = append(ids, c.CardID)
+			}
+		}
+		return ids, msg.MinSelections, msg.MaxSelections, msg.Cancellable, nil
+
+	}
+
+	return ids, 0, 0, true, nil
+
+}
+
+func first(ids []string, n int) []string {
+
+	if n > len(ids) {
+		n = len(ids)
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return ids[:n]
+
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"duel-masters/game/format"
+	"duel-masters/game/match"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// deckSize is the number of cards in the random decks
+const deckSize = 40
+
+// options configure how the games are played
+type options struct {
+	turns   int
+	stall   time.Duration
+	timeout time.Duration
+}
+
+// failure is something that went wrong in a game
+type failure struct {
+	kind    string
+	message string
+	detail  string
+}
+
+// result is the outcome of a game
+type result struct {
+	seed     int64
+	turns    int
+	ended    bool
+	decks    [2][]string
+	failures []failure
+}
+
+// play lets two fuzzers play a match with random decks, everything is derived from the seed
+func play(seed int64, uids []string, opts options, hook *logHook) *result {
+
+	rng := rand.New(rand.NewSource(seed))
+
+	res := &result{seed: seed}
+
+	var mutex sync.Mutex
+	failed := make(chan struct{})
+	var once sync.Once
+
+	report := func(kind string, message string, detail string) {
+
+		mutex.Lock()
+		res.failures = append(res.failures, failure{kind, message, detail})
+		mutex.Unlock()
+
+		once.Do(func() { close(failed) })
+
+	}
+
+	m := match.NewHeadless("fuzz")
+	m.SetSeed(seed)
+
+	f1 := newFuzzer(rng.Int63(), report)
+	f2 := newFuzzer(rng.Int63(), report)
+
+	// status describes the prompts that have not been answered and the warnings
+	// of the players, they usually tell why the match is stuck
+	status := func() []string {
+
+		result := make([]string, 0)
+
+		for _, f := range []*fuzzer{f1, f2} {
+
+			if m.HasPendingPrompt(f.player.Player) {
+				result = append(result, fmt.Sprintf("%s has the prompt \"%s\" pending", f.player.Username, f.Prompt()))
+			}
+
+			if f.Warning() != "" {
+				result = append(result, fmt.Sprintf("%s was last warned \"%s\"", f.player.Username, f.Warning()))
+			}
+
+		}
+
+		return result
+
+	}
+
+	// turns is read from the fuzzers, the match itself can only be looked at from its loop
+	turns := func() int {
+		if f1.Turn() > f2.Turn() {
+			return f1.Turn()
+		}
+		return f2.Turn()
+	}
+
+	hook.Watch(func(kind string, message string, detail string) {
+
+		if kind == "stuck" {
+			message = strings.Join(append([]string{message}, status()...), ", ")
+		}
+
+		report(kind, message, detail)
+
+	})
+	defer hook.Watch(nil)
+
+	p1, _ := m.AddPlayer("fuzzer1", "fuzzer1", f1)
+	p2, _ := m.AddPlayer("fuzzer2", "fuzzer2", f2)
+
+	for i, p := range []*match.PlayerReference{p1, p2} {
+
+		res.decks[i] = randomDeck(rng, uids)
+
+		if err := format.Standard.Validate(res.decks[i]); err != nil {
+			report("setup", err.Error(), "")
+			m.Dispose()
+			return res
+		}
+
+		p.Player.CreateDeck(res.decks[i])
+
+	}
+
+	// Start runs the first turn steps, a panic there is as much a bug as one during the turns
+	m.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				report("panic", fmt.Sprintf("starting the match: %v", r), string(debug.Stack()))
+			}
+		}()
+		m.Start()
+	})
+
+	ticker := time.NewTicker(opts.stall)
+	defer ticker.Stop()
+
+	timeout := time.After(opts.timeout)
+
+	progress := f1.Progress() + f2.Progress()
+
+	for {
+
+		select {
+
+		case <-f1.Done():
+			res.ended = true
+			res.turns = turns()
+			return res
+
+		case <-failed:
+
+		case <-timeout:
+			report("timeout", fmt.Sprintf("the match did not end within %v", opts.timeout), "")
+
+		case <-ticker.C:
+
+			if turns() > opts.turns {
+				break
+			}
+
+			current := f1.Progress() + f2.Progress()
+
+			if current != progress {
+				progress = current
+				continue
+			}
+
+			if !m.HasPendingPrompt(p1.Player) && !m.HasPendingPrompt(p2.Player) {
+				report("deadlock", fmt.Sprintf("the match made no progress for %v and no prompt is pending", opts.stall), blocked())
+			} else {
+				report("stall", strings.Join(append([]string{fmt.Sprintf("the match made no progress for %v", opts.stall)}, status()...), ", "), blocked())
+			}
+
+		}
+
+		res.turns = turns()
+
+		// a match that is stuck is never closed
+		go m.Dispose()
+
+		return res
+
+	}
+
+}
+
+// randomDeck returns a deck of random cards with at most 4 copies of each card
+func randomDeck(rng *rand.Rand, uids []string) []string {
+
+	copies := make(map[string]int)
+	deck := make([]string, 0)
+
+	for len(deck) < deckSize {
+
+		uid := uids[rng.Intn(len(uids))]
+
+		if copies[uid] >= 4 {
+			continue
+		}
+
+		copies[uid]++
+		deck = append(deck, uid)
+
+	}
+
+	return deck
+
+}
+
+// blocked returns the stacks of the goroutines of the game that are waiting on
+// a channel or a lock
+func blocked() string {
+
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+
+	result := make([]string, 0)
+
+	for _, g := range strings.Split(string(buf), "\n\n") {
+
+		// the ticker of every match is always waiting for the next tick
+		if !strings.Contains(g, "duel-masters/game/") || strings.Contains(g, "startTicker") {
+			continue
+		}
+
+		if strings.Contains(g, "[chan receive") || strings.Contains(g, "[chan send") || strings.Contains(g, "[select") || strings.Contains(g, "[semacquire") || strings.Contains(g, "[sync.Mutex.Lock") {
+			result = append(result, g)
+		}
+
+	}
+
+	return strings.Join(result, "\n\n")
+
+}
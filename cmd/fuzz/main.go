@@ -0,0 +1,152 @@
+// Command fuzz plays headless matches between random decks that answer every prompt with
+// a random selection, and reports panics, deadlocks and broken invariants of the match
+// together with the seed to reproduce them
+package main
+
+import (
+	"duel-masters/game/cards"
+	"duel-masters/game/match"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logHook reports the panics that were recovered by the match, the turns that could not be
+// ended and the invariants that were violated, they are only logged by the match
+type logHook struct {
+	mutex sync.Mutex
+	watch func(kind string, message string, detail string)
+}
+
+// Watch calls the function for every reported log entry until it is replaced
+func (h *logHook) Watch(watch func(kind string, message string, detail string)) {
+	h.mutex.Lock()
+	h.watch = watch
+	h.mutex.Unlock()
+}
+
+func (h *logHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.WarnLevel}
+}
+
+func (h *logHook) Fire(entry *logrus.Entry) error {
+
+	kind := ""
+
+	switch {
+	case strings.HasPrefix(entry.Message, "Recovered"):
+		kind = "panic"
+	case strings.Contains(entry.Message, "unable to end its turn"):
+		kind = "stuck"
+	case strings.HasPrefix(entry.Message, "Invariant violated"):
+		kind = "invariant"
+	default:
+		return nil
+	}
+
+	detail, _ := entry.Data["state"].(string)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.watch != nil {
+		h.watch(kind, entry.Message, detail)
+	}
+
+	return nil
+
+}
+
+func main() {
+
+	games := flag.Int("games", 100, "number of matches to play")
+	seed := flag.Int64("seed", 0, "seed of the first match, the following matches use the next seeds. Defaults to the current time")
+	turns := flag.Int("turns", 100, "number of turns after which a match is stopped")
+	stall := flag.Duration("stall", 5*time.Second, "time without progress after which a match is reported as stuck")
+	timeout := flag.Duration("timeout", 2*time.Minute, "time after which a match is reported as stuck")
+	verbose := flag.Bool("v", false, "print the logs of the matches")
+	flag.Parse()
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	match.CheckInvariants = true
+
+	hook := &logHook{}
+
+	logrus.AddHook(hook)
+	logrus.SetLevel(logrus.WarnLevel)
+	logrus.SetOutput(ioutil.Discard)
+
+	if *verbose {
+		logrus.SetLevel(logrus.DebugLevel)
+		logrus.SetOutput(os.Stderr)
+	}
+
+	uids := make([]string, 0)
+
+	cards.RegisterAll()
+
+	for _, set := range cards.Sets {
+		for uid := range *set {
+			uids = append(uids, uid)
+		}
+	}
+
+	sort.Strings(uids)
+
+	opts := options{
+		turns:   *turns,
+		stall:   *stall,
+		timeout: *timeout,
+	}
+
+	ended := 0
+	failed := 0
+
+	for i := 0; i < *games; i++ {
+
+		res := play(*seed+int64(i), uids, opts, hook)
+
+		if len(res.failures) < 1 {
+			if res.ended {
+				ended++
+			}
+			continue
+		}
+
+		failed++
+
+		fmt.Printf("FAIL seed %v after %v turns, reproduce with: go run ./cmd/fuzz -games 1 -seed %v\n", res.seed, res.turns, res.seed)
+
+		for _, f := range res.failures {
+
+			fmt.Printf("  %s: %s\n", f.kind, f.message)
+
+			if f.detail != "" {
+				fmt.Printf("\n%s\n\n", f.detail)
+			}
+
+		}
+
+		for i, deck := range res.decks {
+			fmt.Printf("  deck of fuzzer%v: %s\n", i+1, strings.Join(deck, ","))
+		}
+
+	}
+
+	fmt.Printf("Played %v matches from seed %v: %v ended, %v reached the turn limit, %v failed\n", *games, *seed, ended, *games-ended-failed, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+}
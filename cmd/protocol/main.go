@@ -0,0 +1,34 @@
+// Command protocol writes the JSON Schema and the message catalog of the websocket protocol
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path"
+
+	"duel-masters/server"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+
+	out := flag.String("out", "docs", "directory to write protocol.schema.json and protocol.md to")
+	flag.Parse()
+
+	schema, err := json.MarshalIndent(server.Schema(), "", "  ")
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path.Join(*out, "protocol.schema.json"), append(schema, '\n'), 0644); err != nil {
+		logrus.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path.Join(*out, "protocol.md"), []byte(server.Catalog()), 0644); err != nil {
+		logrus.Fatal(err)
+	}
+
+}
@@ -1,54 +1,56 @@
 package db
 
-import (
-	"context"
-
-	"github.com/sirupsen/logrus"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
-)
-
-var conn *mongo.Database
+// GetUserForToken returns a user from the authorization header or returns an error
+func GetUserForToken(token string) (User, error) {
+	return Sessions().GetUser(token)
+}
 
-// Connect connects to the database
-func Connect(connectionString string, dbName string) {
+// SaveMatch stores the result of a finished match. If the match has a winner or ended
+// in a draw the rating of both players is updated together with storing the result
+func SaveMatch(match Match) error {
+	return Matches().Save(match)
+}
 
-	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(connectionString))
+// SaveReplay stores the event log of a match
+func SaveReplay(replay Replay) error {
+	return Replays().Save(replay)
+}
 
-	if err != nil {
-		logrus.Fatal(err)
-	}
+// GetReplay returns the event log of the match with the specified id
+func GetReplay(uid string) (Replay, error) {
+	return Replays().Get(uid)
+}
 
-	err = client.Ping(context.TODO(), readpref.Primary())
+// rateMatch sets the ratings of the players before and after a match with a winner or a draw,
+// rating returns the current rating of a player. False is returned if the match is not rated
+func rateMatch(match *Match, rating func(uid string) (int, error)) (bool, error) {
 
-	if err != nil {
-		logrus.Fatal(err)
+	if (match.Winner == "" && !match.Draw) || len(match.Players) != 2 {
+		return false, nil
 	}
 
-	conn = client.Database(dbName)
-
-	logrus.Info("Connected to database")
+	for i, player := range match.Players {
 
-}
+		r, err := rating(player.UID)
 
-// Collection returns a mongodb collection handle
-func Collection(collectionName string) *mongo.Collection {
-	return conn.Collection(collectionName)
-}
+		if err != nil {
+			return false, err
+		}
 
-// GetUserForToken returns a user from the authorization header or returns an error
-func GetUserForToken(token string) (User, error) {
+		match.Players[i].RatingBefore = r
 
-	collection := Collection("users")
+	}
 
-	var user User
+	score := 0.0
 
-	if err := collection.FindOne(context.TODO(), bson.M{"sessions": bson.M{"$elemMatch": bson.M{"token": token}}}).Decode(&user); err != nil {
-		return User{}, err
+	if match.Draw {
+		score = 0.5
+	} else if match.Winner == match.Players[0].UID {
+		score = 1
 	}
 
-	return user, nil
+	match.Players[0].RatingAfter, match.Players[1].RatingAfter = Elo(match.Players[0].RatingBefore, match.Players[1].RatingBefore, score)
+
+	return true, nil
 
 }
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRateMatch(t *testing.T) {
+
+	ratings := map[string]int{"a": 1200, "b": 1200}
+
+	rating := func(uid string) (int, error) {
+
+		r, ok := ratings[uid]
+
+		if !ok {
+			// a user without a rated match yet
+			return User{}.EffectiveRating(), nil
+		}
+
+		return r, nil
+
+	}
+
+	tests := []struct {
+		name   string
+		match  Match
+		rated  bool
+		before [2]int
+		after  [2]int
+	}{
+		{"win", Match{Winner: "a", Players: []MatchPlayer{{UID: "a"}, {UID: "b"}}}, true, [2]int{1200, 1200}, [2]int{1216, 1184}},
+		{"loss", Match{Winner: "b", Players: []MatchPlayer{{UID: "a"}, {UID: "b"}}}, true, [2]int{1200, 1200}, [2]int{1184, 1216}},
+		{"draw", Match{Draw: true, Players: []MatchPlayer{{UID: "a"}, {UID: "b"}}}, true, [2]int{1200, 1200}, [2]int{1200, 1200}},
+		{"new player", Match{Winner: "new", Players: []MatchPlayer{{UID: "new"}, {UID: "b"}}}, true, [2]int{1200, 1200}, [2]int{1216, 1184}},
+		{"no winner", Match{Players: []MatchPlayer{{UID: "a"}, {UID: "b"}}}, false, [2]int{}, [2]int{}},
+		{"one player", Match{Winner: "a", Players: []MatchPlayer{{UID: "a"}}}, false, [2]int{}, [2]int{}},
+	}
+
+	for _, test := range tests {
+
+		rated, err := rateMatch(&test.match, rating)
+
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		if rated != test.rated {
+			t.Errorf("%s: expected rated to be %v", test.name, test.rated)
+		}
+
+		if !rated {
+			continue
+		}
+
+		p := test.match.Players
+
+		if [2]int{p[0].RatingBefore, p[1].RatingBefore} != test.before || [2]int{p[0].RatingAfter, p[1].RatingAfter} != test.after {
+			t.Errorf("%s: expected %v -> %v, got %+v", test.name, test.before, test.after, p)
+		}
+
+	}
+
+	failed := errors.New("failed")
+
+	_, err := rateMatch(&Match{Winner: "a", Players: []MatchPlayer{{UID: "a"}, {UID: "b"}}}, func(uid string) (int, error) {
+		return 0, failed
+	})
+
+	if err != failed {
+		t.Errorf("Expected the error of the rating lookup, got %v", err)
+	}
+
+}
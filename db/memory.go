@@ -0,0 +1,363 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// memory holds all data of the in-memory storage backend
+type memory struct {
+	users   map[string]User
+	decks   map[string]Deck
+	matches []Match
+	replays map[string]Replay
+	mutex   sync.Mutex
+}
+
+// NewMemoryStorage returns a storage backend that keeps everything in memory,
+// all data is lost when the server is stopped
+func NewMemoryStorage() *Storage {
+
+	m := &memory{
+		users:   make(map[string]User),
+		decks:   make(map[string]Deck),
+		matches: make([]Match, 0),
+		replays: make(map[string]Replay),
+	}
+
+	return &Storage{
+		Users:    &memoryUsers{m},
+		Sessions: &memorySessions{m},
+		Decks:    &memoryDecks{m},
+		Matches:  &memoryMatches{m},
+		Replays:  &memoryReplays{m},
+	}
+
+}
+
+// copyUser returns a copy of the user that does not share any slices with it
+func copyUser(user User) User {
+	user.Permissions = append([]string{}, user.Permissions...)
+	user.Sessions = append([]UserSession{}, user.Sessions...)
+	return user
+}
+
+// copyDeck returns a copy of the deck that does not share any slices with it
+func copyDeck(deck Deck) Deck {
+	deck.Cards = append([]string{}, deck.Cards...)
+	return deck
+}
+
+type memoryUsers struct {
+	m *memory
+}
+
+func (s *memoryUsers) Get(uid string) (User, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	user, ok := s.m.users[uid]
+
+	if !ok {
+		return User{}, ErrNotFound
+	}
+
+	return copyUser(user), nil
+
+}
+
+// find returns the first user the function returns true for
+func (s *memoryUsers) find(f func(User) bool) (User, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	for _, user := range s.m.users {
+		if f(user) {
+			return copyUser(user), nil
+		}
+	}
+
+	return User{}, ErrNotFound
+
+}
+
+func (s *memoryUsers) GetByUsername(username string) (User, error) {
+	return s.find(func(u User) bool { return strings.EqualFold(u.Username, username) })
+}
+
+func (s *memoryUsers) GetByEmail(email string) (User, error) {
+	return s.find(func(u User) bool { return strings.EqualFold(u.Email, email) })
+}
+
+func (s *memoryUsers) GetBots(owner string) ([]User, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	bots := make([]User, 0)
+
+	for _, user := range s.m.users {
+		if user.Bot && user.Owner == owner {
+			bots = append(bots, copyUser(user))
+		}
+	}
+
+	sort.SliceStable(bots, func(i, j int) bool {
+		return bots[i].Username < bots[j].Username
+	})
+
+	return bots, nil
+
+}
+
+func (s *memoryUsers) Create(user User) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	s.m.users[user.UID] = copyUser(user)
+
+	return nil
+
+}
+
+type memorySessions struct {
+	m *memory
+}
+
+func (s *memorySessions) Add(uid string, session UserSession) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	user, ok := s.m.users[uid]
+
+	if !ok {
+		return ErrNotFound
+	}
+
+	user.Sessions = append(append([]UserSession{}, user.Sessions...), session)
+	s.m.users[uid] = user
+
+	return nil
+
+}
+
+func (s *memorySessions) Replace(uid string, session UserSession) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	user, ok := s.m.users[uid]
+
+	if !ok {
+		return ErrNotFound
+	}
+
+	user.Sessions = []UserSession{session}
+	s.m.users[uid] = user
+
+	return nil
+
+}
+
+func (s *memorySessions) GetUser(token string) (User, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	for _, user := range s.m.users {
+		for _, session := range user.Sessions {
+			if session.Token == token {
+				return copyUser(user), nil
+			}
+		}
+	}
+
+	return User{}, ErrNotFound
+
+}
+
+type memoryDecks struct {
+	m *memory
+}
+
+func (s *memoryDecks) Get(uid string) (Deck, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	deck, ok := s.m.decks[uid]
+
+	if !ok {
+		return Deck{}, ErrNotFound
+	}
+
+	return copyDeck(deck), nil
+
+}
+
+// filter returns the decks the function returns true for, ordered by name
+func (s *memoryDecks) filter(f func(Deck) bool) []Deck {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	decks := make([]Deck, 0)
+
+	for _, deck := range s.m.decks {
+		if f(deck) {
+			decks = append(decks, copyDeck(deck))
+		}
+	}
+
+	sort.SliceStable(decks, func(i, j int) bool {
+		return decks[i].Name < decks[j].Name
+	})
+
+	return decks
+
+}
+
+func (s *memoryDecks) GetByOwner(owner string) ([]Deck, error) {
+	return s.filter(func(d Deck) bool { return d.Owner == owner }), nil
+}
+
+func (s *memoryDecks) GetAvailable(owner string) ([]Deck, error) {
+	return s.filter(func(d Deck) bool { return d.Owner == owner || d.Standard }), nil
+}
+
+func (s *memoryDecks) Count(owner string) (int, error) {
+	return len(s.filter(func(d Deck) bool { return d.Owner == owner })), nil
+}
+
+func (s *memoryDecks) Create(deck Deck) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	s.m.decks[deck.UID] = copyDeck(deck)
+
+	return nil
+
+}
+
+func (s *memoryDecks) Update(deck Deck) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	existing, ok := s.m.decks[deck.UID]
+
+	if !ok || existing.Owner != deck.Owner {
+		return nil
+	}
+
+	existing.Name = deck.Name
+	existing.Public = deck.Public
+	existing.Cards = append([]string{}, deck.Cards...)
+
+	s.m.decks[deck.UID] = existing
+
+	return nil
+
+}
+
+func (s *memoryDecks) Delete(uid string, owner string) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	deck, ok := s.m.decks[uid]
+
+	if !ok || deck.Owner != owner {
+		return ErrNotFound
+	}
+
+	delete(s.m.decks, uid)
+
+	return nil
+
+}
+
+type memoryMatches struct {
+	m *memory
+}
+
+// Save stores the result and updates the ratings while holding the lock, saving a
+// match that was saved before changes nothing
+func (s *memoryMatches) Save(match Match) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	for _, saved := range s.m.matches {
+		if saved.UID == match.UID {
+			return nil
+		}
+	}
+
+	match.Players = append([]MatchPlayer{}, match.Players...)
+
+	rated, err := rateMatch(&match, func(uid string) (int, error) {
+
+		user, ok := s.m.users[uid]
+
+		if !ok {
+			return 0, ErrNotFound
+		}
+
+		return user.EffectiveRating(), nil
+
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if rated {
+		for _, player := range match.Players {
+			user := s.m.users[player.UID]
+			user.Rating = player.RatingAfter
+			s.m.users[player.UID] = user
+		}
+	}
+
+	s.m.matches = append(s.m.matches, match)
+
+	return nil
+
+}
+
+type memoryReplays struct {
+	m *memory
+}
+
+func (s *memoryReplays) Save(replay Replay) error {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	s.m.replays[replay.UID] = replay
+
+	return nil
+
+}
+
+func (s *memoryReplays) Get(uid string) (Replay, error) {
+
+	s.m.mutex.Lock()
+	defer s.m.mutex.Unlock()
+
+	replay, ok := s.m.replays[uid]
+
+	if !ok {
+		return Replay{}, ErrNotFound
+	}
+
+	return replay, nil
+
+}
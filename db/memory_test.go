@@ -0,0 +1,116 @@
+package db_test
+
+import (
+	"duel-masters/db"
+	"testing"
+)
+
+func TestMemoryStorage(t *testing.T) {
+
+	s := db.NewMemoryStorage()
+
+	for _, uid := range []string{"a", "b"} {
+		if err := s.Users.Create(db.User{UID: uid, Username: "User " + uid, Email: uid + "@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Sessions.Add("a", db.UserSession{Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if user, err := s.Sessions.GetUser("token"); err != nil || user.UID != "a" {
+		t.Errorf("Expected the session to belong to a, got %v %v", user.UID, err)
+	}
+
+	if err := s.Sessions.Replace("a", db.UserSession{Token: "new token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Sessions.GetUser("token"); err != db.ErrNotFound {
+		t.Errorf("Expected the replaced session to be removed, got %v", err)
+	}
+
+	if user, err := s.Sessions.GetUser("new token"); err != nil || user.UID != "a" {
+		t.Errorf("Expected the new session to belong to a, got %v %v", user.UID, err)
+	}
+
+	if user, err := s.Users.GetByUsername("user B"); err != nil || user.UID != "b" {
+		t.Errorf("Expected to find b by username ignoring case, got %v %v", user.UID, err)
+	}
+
+	s.Users.Create(db.User{UID: "bot", Username: "Bot", Bot: true, Owner: "a"})
+
+	if bots, _ := s.Users.GetBots("a"); len(bots) != 1 || bots[0].UID != "bot" {
+		t.Errorf("Expected a to own one bot, got %v", bots)
+	}
+
+	s.Decks.Create(db.Deck{UID: "d1", Owner: "a", Name: "Deck", Cards: []string{"x"}})
+	s.Decks.Create(db.Deck{UID: "d2", Owner: "c", Name: "Standard", Standard: true})
+
+	if decks, _ := s.Decks.GetAvailable("a"); len(decks) != 2 {
+		t.Errorf("Expected the deck of a and the standard deck to be available, got %v", len(decks))
+	}
+
+	s.Decks.Update(db.Deck{UID: "d1", Owner: "b", Name: "Stolen"})
+
+	if deck, _ := s.Decks.Get("d1"); deck.Name != "Deck" {
+		t.Errorf("Expected only the owner to be able to update a deck, the name is %s", deck.Name)
+	}
+
+	if err := s.Decks.Delete("d1", "b"); err != db.ErrNotFound {
+		t.Errorf("Expected ErrNotFound when deleting another user's deck, got %v", err)
+	}
+
+	err := s.Matches.Save(db.Match{
+		UID:     "m",
+		Winner:  "a",
+		Players: []db.MatchPlayer{{UID: "a"}, {UID: "b"}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := s.Users.Get("a")
+	b, _ := s.Users.Get("b")
+
+	if a.Rating != db.DefaultRating+16 || b.Rating != db.DefaultRating-16 {
+		t.Errorf("Expected the ratings to be updated after the match, got %v and %v", a.Rating, b.Rating)
+	}
+
+	err = s.Matches.Save(db.Match{
+		UID:     "m",
+		Winner:  "a",
+		Players: []db.MatchPlayer{{UID: "a"}, {UID: "b"}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ = s.Users.Get("a")
+	b, _ = s.Users.Get("b")
+
+	if a.Rating != db.DefaultRating+16 || b.Rating != db.DefaultRating-16 {
+		t.Errorf("Expected saving the match again not to change the ratings, got %v and %v", a.Rating, b.Rating)
+	}
+
+	err = s.Matches.Save(db.Match{
+		UID:     "draw",
+		Draw:    true,
+		Players: []db.MatchPlayer{{UID: "a"}, {UID: "b"}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ = s.Users.Get("a")
+	b, _ = s.Users.Get("b")
+
+	if a.Rating != db.DefaultRating+15 || b.Rating != db.DefaultRating-15 {
+		t.Errorf("Expected the higher rated player to lose a point in a draw, got %v and %v", a.Rating, b.Rating)
+	}
+
+}
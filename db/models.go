@@ -1,6 +1,9 @@
 package db
 
-// UserSession struct holds the users session information
+import "encoding/json"
+
+// UserSession struct holds the users session information, the API tokens
+// of bots are sessions that do not expire and have an Expires of 0
 type UserSession struct {
 	Token   string `json:"token"`
 	IP      string `json:"ip"`
@@ -15,7 +18,10 @@ type User struct {
 	Password    string        `json:"-"`
 	Email       string        `json:"email"`
 	Color       string        `json:"color"`
+	Rating      int           `json:"rating"`
 	Sessions    []UserSession `json:"-"`
+	Bot         bool          `json:"bot"`
+	Owner       string        `json:"owner,omitempty"`
 }
 
 // Deck struct is a player deck
@@ -27,3 +33,69 @@ type Deck struct {
 	Standard bool     `json:"standard"`
 	Cards    []string `json:"cards"`
 }
+
+// MatchPlayer holds information about one of the players in a finished match
+type MatchPlayer struct {
+	UID          string `json:"uid"`
+	Username     string `json:"username"`
+	Deck         string `json:"deck"`
+	RatingBefore int    `json:"ratingBefore"`
+	RatingAfter  int    `json:"ratingAfter"`
+}
+
+// Match struct holds the result of a finished match
+type Match struct {
+	UID       string        `json:"uid"`
+	Name      string        `json:"name"`
+	Players   []MatchPlayer `json:"players"`
+	Winner    string        `json:"winner"`
+	WinReason string        `json:"winReason"`
+	Draw      bool          `json:"draw"`
+	Series    string        `json:"series,omitempty"`
+	Turns     int           `json:"turns"`
+	Seed      int64         `json:"seed"`
+	Started   int64         `json:"started"`
+	Ended     int64         `json:"ended"`
+
+	// SeriesResult is the final result of the series, stored with its last game
+	SeriesResult *SeriesResult `json:"seriesResult,omitempty"`
+}
+
+// SeriesResult holds the final result of a best-of-N series
+type SeriesResult struct {
+	UID    string         `json:"uid"`
+	Games  int            `json:"games"`
+	Wins   map[string]int `json:"wins"`
+	Winner string         `json:"winner"`
+	// Reason is "forfeit" or "abandoned" if the series ended because a player left
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReplayPlayer holds information about one of the players in a recorded match
+type ReplayPlayer struct {
+	UID      string   `json:"uid"`
+	Username string   `json:"username"`
+	Deck     string   `json:"deck"`
+	Cards    []string `json:"cards"`
+}
+
+// ReplayEvent is a message that was sent to or received from the players of a match
+type ReplayEvent struct {
+	Time   int64           `json:"time"`
+	Player string          `json:"player"`
+	Header string          `json:"header"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Replay struct holds the recorded event log of a match. Players are listed in the
+// order their decks were created, which together with the seed and the messages
+// received from the players is enough to play the match out again
+type Replay struct {
+	UID     string         `json:"uid"`
+	Name    string         `json:"name"`
+	Seed    int64          `json:"seed"`
+	Players []ReplayPlayer `json:"players"`
+	Events  []ReplayEvent  `json:"events"`
+	Started int64          `json:"started"`
+	Ended   int64          `json:"ended"`
+}
@@ -0,0 +1,386 @@
+package db
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Connect connects to the database and uses it as the storage backend
+func Connect(connectionString string, dbName string) {
+
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(connectionString))
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	err = client.Ping(context.TODO(), readpref.Primary())
+
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	Use(NewMongoStorage(client.Database(dbName)))
+
+	logrus.Info("Connected to database")
+
+}
+
+// NewMongoStorage returns a storage backend that stores everything in the mongodb database
+func NewMongoStorage(conn *mongo.Database) *Storage {
+
+	users := &mongoUsers{conn.Collection("users")}
+
+	return &Storage{
+		Users:    users,
+		Sessions: &mongoSessions{users.collection},
+		Decks:    &mongoDecks{conn.Collection("decks")},
+		Matches:  &mongoMatches{conn: conn, collection: conn.Collection("matches"), users: users.collection},
+		Replays:  &mongoReplays{conn.Collection("replays")},
+	}
+
+}
+
+// findOne decodes the first document matching the filter into v
+func findOne(collection *mongo.Collection, filter interface{}, v interface{}) error {
+
+	err := collection.FindOne(context.TODO(), filter).Decode(v)
+
+	if err == mongo.ErrNoDocuments {
+		return ErrNotFound
+	}
+
+	return err
+
+}
+
+// equalFold returns a filter for documents where the field equals the value, ignoring case
+func equalFold(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
+
+type mongoUsers struct {
+	collection *mongo.Collection
+}
+
+func (s *mongoUsers) Get(uid string) (User, error) {
+
+	var user User
+
+	if err := findOne(s.collection, bson.M{"uid": uid}, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+func (s *mongoUsers) GetByUsername(username string) (User, error) {
+
+	var user User
+
+	if err := findOne(s.collection, bson.M{"username": equalFold(username)}, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+func (s *mongoUsers) GetByEmail(email string) (User, error) {
+
+	var user User
+
+	if err := findOne(s.collection, bson.M{"email": equalFold(email)}, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+func (s *mongoUsers) GetBots(owner string) ([]User, error) {
+
+	cur, err := s.collection.Find(context.TODO(), bson.M{"bot": true, "owner": owner}, options.Find().SetSort(bson.M{"username": 1}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(context.TODO())
+
+	bots := make([]User, 0)
+
+	for cur.Next(context.TODO()) {
+
+		var user User
+
+		if err := cur.Decode(&user); err != nil {
+			continue
+		}
+
+		bots = append(bots, user)
+
+	}
+
+	return bots, nil
+
+}
+
+func (s *mongoUsers) Create(user User) error {
+
+	_, err := s.collection.InsertOne(context.TODO(), user)
+
+	return err
+
+}
+
+type mongoSessions struct {
+	collection *mongo.Collection
+}
+
+func (s *mongoSessions) Add(uid string, session UserSession) error {
+
+	_, err := s.collection.UpdateOne(context.TODO(), bson.M{"uid": uid}, bson.M{"$push": bson.M{"sessions": session}})
+
+	return err
+
+}
+
+func (s *mongoSessions) Replace(uid string, session UserSession) error {
+
+	_, err := s.collection.UpdateOne(context.TODO(), bson.M{"uid": uid}, bson.M{"$set": bson.M{"sessions": []UserSession{session}}})
+
+	return err
+
+}
+
+func (s *mongoSessions) GetUser(token string) (User, error) {
+
+	var user User
+
+	if err := findOne(s.collection, bson.M{"sessions": bson.M{"$elemMatch": bson.M{"token": token}}}, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+type mongoDecks struct {
+	collection *mongo.Collection
+}
+
+func (s *mongoDecks) Get(uid string) (Deck, error) {
+
+	var deck Deck
+
+	if err := findOne(s.collection, bson.M{"uid": uid}, &deck); err != nil {
+		return Deck{}, err
+	}
+
+	return deck, nil
+
+}
+
+// find returns all decks that match the filter, decks that can't be decoded are skipped
+func (s *mongoDecks) find(filter interface{}) ([]Deck, error) {
+
+	cur, err := s.collection.Find(context.TODO(), filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(context.TODO())
+
+	decks := make([]Deck, 0)
+
+	for cur.Next(context.TODO()) {
+
+		var deck Deck
+
+		if err := cur.Decode(&deck); err != nil {
+			continue
+		}
+
+		decks = append(decks, deck)
+
+	}
+
+	return decks, nil
+
+}
+
+func (s *mongoDecks) GetByOwner(owner string) ([]Deck, error) {
+	return s.find(bson.M{"owner": owner})
+}
+
+func (s *mongoDecks) GetAvailable(owner string) ([]Deck, error) {
+	return s.find(bson.M{
+		"$or": []bson.M{
+			{"owner": owner},
+			{"standard": true},
+		},
+	})
+}
+
+func (s *mongoDecks) Count(owner string) (int, error) {
+
+	n, err := s.collection.CountDocuments(context.TODO(), bson.M{"owner": owner})
+
+	return int(n), err
+
+}
+
+func (s *mongoDecks) Create(deck Deck) error {
+
+	_, err := s.collection.InsertOne(context.TODO(), deck)
+
+	return err
+
+}
+
+func (s *mongoDecks) Update(deck Deck) error {
+
+	_, err := s.collection.UpdateOne(
+		context.TODO(),
+		bson.M{"uid": deck.UID, "owner": deck.Owner},
+		bson.M{"$set": bson.M{"name": deck.Name, "public": deck.Public, "cards": deck.Cards}},
+	)
+
+	return err
+
+}
+
+func (s *mongoDecks) Delete(uid string, owner string) error {
+
+	result, err := s.collection.DeleteOne(context.TODO(), bson.M{"uid": uid, "owner": owner})
+
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+
+}
+
+type mongoMatches struct {
+	conn       *mongo.Database
+	collection *mongo.Collection
+	users      *mongo.Collection
+}
+
+// Save stores the result and updates the ratings within the same transaction, saving
+// a match that was saved before changes nothing
+func (s *mongoMatches) Save(match Match) error {
+
+	session, err := s.conn.Client().StartSession()
+
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(sc mongo.SessionContext) (interface{}, error) {
+
+		rated, err := rateMatch(&match, func(uid string) (int, error) {
+
+			var user User
+
+			if err := s.users.FindOne(sc, bson.M{"uid": uid}).Decode(&user); err != nil {
+				return 0, err
+			}
+
+			return user.EffectiveRating(), nil
+
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.collection.UpdateOne(
+			sc,
+			bson.M{"uid": match.UID},
+			bson.M{"$setOnInsert": match},
+			options.Update().SetUpsert(true),
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The match was saved before, together with the ratings
+		if result.UpsertedCount < 1 || !rated {
+			return nil, nil
+		}
+
+		for _, player := range match.Players {
+
+			// users that have not played a rated match yet start from the default rating
+			_, err := s.users.UpdateOne(
+				sc,
+				bson.M{"uid": player.UID, "rating": bson.M{"$in": bson.A{0, nil}}},
+				bson.M{"$set": bson.M{"rating": DefaultRating}},
+			)
+
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = s.users.UpdateOne(
+				sc,
+				bson.M{"uid": player.UID},
+				bson.M{"$inc": bson.M{"rating": player.RatingAfter - player.RatingBefore}},
+			)
+
+			if err != nil {
+				return nil, err
+			}
+
+		}
+
+		return nil, nil
+
+	})
+
+	return err
+
+}
+
+type mongoReplays struct {
+	collection *mongo.Collection
+}
+
+func (s *mongoReplays) Save(replay Replay) error {
+
+	_, err := s.collection.InsertOne(context.TODO(), replay)
+
+	return err
+
+}
+
+func (s *mongoReplays) Get(uid string) (Replay, error) {
+
+	var replay Replay
+
+	if err := findOne(s.collection, bson.M{"uid": uid}, &replay); err != nil {
+		return Replay{}, err
+	}
+
+	return replay, nil
+
+}
@@ -0,0 +1,34 @@
+package db
+
+import "math"
+
+const (
+	// DefaultRating is the rating new players start out with
+	DefaultRating = 1200
+	// ratingFactor is the maximum rating change from a single match
+	ratingFactor = 32
+)
+
+// EffectiveRating returns the users rating, or the default rating for users that
+// have not yet played a rated match
+func (u User) EffectiveRating() int {
+
+	if u.Rating == 0 {
+		return DefaultRating
+	}
+
+	return u.Rating
+
+}
+
+// Elo calculates the new ratings of player a and b after a match
+// score is the result for player a, 1 for a win, 0.5 for a draw and 0 for a loss
+func Elo(a int, b int, score float64) (int, int) {
+
+	expected := 1 / (1 + math.Pow(10, float64(b-a)/400))
+
+	delta := int(math.Round(ratingFactor * (score - expected)))
+
+	return a + delta, b - delta
+
+}
@@ -0,0 +1,46 @@
+package db_test
+
+import (
+	"duel-masters/db"
+	"testing"
+)
+
+func TestElo(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		a, b  int
+		score float64
+		want  [2]int
+	}{
+		{"win", 1200, 1200, 1, [2]int{1216, 1184}},
+		{"loss", 1200, 1200, 0, [2]int{1184, 1216}},
+		{"draw", 1200, 1200, 0.5, [2]int{1200, 1200}},
+		{"draw against a weaker player", 1400, 1200, 0.5, [2]int{1392, 1208}},
+		{"win against a much stronger player", 1200, 2800, 1, [2]int{1232, 2768}},
+		{"loss against a much weaker player", 2800, 1200, 0, [2]int{2768, 1232}},
+	}
+
+	for _, test := range tests {
+
+		a, b := db.Elo(test.a, test.b, test.score)
+
+		if a != test.want[0] || b != test.want[1] {
+			t.Errorf("%s: expected %v, got [%v %v]", test.name, test.want, a, b)
+		}
+
+	}
+
+}
+
+func TestEffectiveRating(t *testing.T) {
+
+	if r := (db.User{}).EffectiveRating(); r != 1200 {
+		t.Errorf("Expected new players to start at 1200, got %v", r)
+	}
+
+	if r := (db.User{Rating: 1500}).EffectiveRating(); r != 1500 {
+		t.Errorf("Expected the rating of the user, got %v", r)
+	}
+
+}
@@ -0,0 +1,107 @@
+package db
+
+import "errors"
+
+// ErrNotFound is returned when the requested document does not exist
+var ErrNotFound = errors.New("Not found")
+
+// UserStore stores the user accounts
+type UserStore interface {
+	// Get returns the user with the specified uid
+	Get(uid string) (User, error)
+	// GetByUsername returns the user with the username, ignoring case
+	GetByUsername(username string) (User, error)
+	// GetByEmail returns the user with the email, ignoring case
+	GetByEmail(email string) (User, error)
+	// GetBots returns the bot users created by the owner
+	GetBots(owner string) ([]User, error)
+	// Create stores a new user
+	Create(user User) error
+}
+
+// SessionStore stores the sessions of the users
+type SessionStore interface {
+	// Add adds a session to the user with the specified uid
+	Add(uid string, session UserSession) error
+	// Replace removes every session of the user with the specified uid and adds the session
+	Replace(uid string, session UserSession) error
+	// GetUser returns the user that has a session with the token
+	GetUser(token string) (User, error)
+}
+
+// DeckStore stores the decks of the users and the standard decks
+type DeckStore interface {
+	// Get returns the deck with the specified uid
+	Get(uid string) (Deck, error)
+	// GetByOwner returns the decks of the user
+	GetByOwner(owner string) ([]Deck, error)
+	// GetAvailable returns the decks of the user and the standard decks
+	GetAvailable(owner string) ([]Deck, error)
+	// Count returns the number of decks of the user
+	Count(owner string) (int, error)
+	// Create stores a new deck
+	Create(deck Deck) error
+	// Update changes the name, visibility and cards of the deck,
+	// nothing is changed if the owner does not have a deck with the uid
+	Update(deck Deck) error
+	// Delete removes the deck with the uid from the owner's decks,
+	// ErrNotFound is returned if the owner does not have it
+	Delete(uid string, owner string) error
+}
+
+// MatchStore stores the results of finished matches
+type MatchStore interface {
+	// Save stores the result of a finished match. If the match is rated the rating
+	// of both players is updated in the same write as the result, either both are
+	// stored or neither is. Saving a match that was saved before changes nothing
+	Save(match Match) error
+}
+
+// ReplayStore stores the recorded event logs of matches
+type ReplayStore interface {
+	// Save stores the event log of a match
+	Save(replay Replay) error
+	// Get returns the event log of the match with the specified uid
+	Get(uid string) (Replay, error)
+}
+
+// Storage is a backend that stores all data of the server
+type Storage struct {
+	Users    UserStore
+	Sessions SessionStore
+	Decks    DeckStore
+	Matches  MatchStore
+	Replays  ReplayStore
+}
+
+var storage *Storage
+
+// Use sets the storage backend of the server
+func Use(s *Storage) {
+	storage = s
+}
+
+// Users returns the user store of the current storage backend
+func Users() UserStore {
+	return storage.Users
+}
+
+// Sessions returns the session store of the current storage backend
+func Sessions() SessionStore {
+	return storage.Sessions
+}
+
+// Decks returns the deck store of the current storage backend
+func Decks() DeckStore {
+	return storage.Decks
+}
+
+// Matches returns the match store of the current storage backend
+func Matches() MatchStore {
+	return storage.Matches
+}
+
+// Replays returns the replay store of the current storage backend
+func Replays() ReplayStore {
+	return storage.Replays
+}
@@ -0,0 +1,662 @@
+package bot
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/cnd"
+	"duel-masters/game/format"
+	"duel-masters/game/match"
+	"duel-masters/metrics"
+	"duel-masters/server"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// UID and Username identify the computer player in a match
+const (
+	UID      = "computer"
+	Username = "Computer"
+)
+
+// zones the bot looks through to find the cards of a prompt
+var zones = []string{
+	match.HAND,
+	match.BATTLEZONE,
+	match.MANAZONE,
+	match.SHIELDZONE,
+	match.GRAVEYARD,
+	match.DECK,
+	match.SPELLZONE,
+	match.HIDDENZONE,
+}
+
+// Bot is a computer controlled player. It chooses a standard deck, answers the prompts
+// of the match and charges mana, summons creatures and attacks on its own during its turns
+type Bot struct {
+	// Delay is the time the bot waits before each of its commands,
+	// so that the opponent can follow what it is doing
+	Delay time.Duration
+
+	match    *match.Match
+	player   *match.PlayerReference
+	prompt   interface{}
+	attempts int
+	target   string
+	mutex    sync.Mutex
+	done     chan struct{}
+	once     sync.Once
+}
+
+// New returns a new bot
+func New() *Bot {
+	return &Bot{
+		Delay: time.Second,
+		done:  make(chan struct{}),
+	}
+}
+
+// Attach stores the match and player the bot is playing for
+func (b *Bot) Attach(m *match.Match, p *match.PlayerReference) {
+	b.match = m
+	b.player = p
+}
+
+// Send handles the messages the match sends to the bot's player
+func (b *Bot) Send(msg interface{}) {
+
+	switch msg := msg.(type) {
+
+	case server.DecksMessage:
+		if msg.Header == "choose_deck" {
+			go b.chooseDeck(msg.Decks)
+		}
+
+	case *server.ActionMessage, *server.MultipartActionMessage:
+		b.mutex.Lock()
+		b.prompt = msg
+		b.attempts = 0
+		b.mutex.Unlock()
+		b.respond()
+
+	case server.ActionWarningMessage:
+		// The previous selection did not meet the requirements and the
+		// match is still waiting for a response to the same prompt
+		b.mutex.Lock()
+		b.attempts++
+		b.mutex.Unlock()
+		b.respond()
+
+	case server.Message:
+		if msg.Header == "close_action" {
+			b.mutex.Lock()
+			b.prompt = nil
+			b.mutex.Unlock()
+		}
+
+	}
+
+}
+
+// Close is called when the match is disposed
+func (b *Bot) Close() {
+	b.once.Do(func() { close(b.done) })
+}
+
+// Done returns a channel that is closed when the match is disposed
+func (b *Bot) Done() <-chan struct{} {
+	return b.done
+}
+
+// chooseDeck picks one of the legal standard decks at random
+func (b *Bot) chooseDeck(decks []db.Deck) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from bot choosing a deck. %v", r)
+			metrics.Panics.Inc("bot_choose_deck")
+		}
+	}()
+
+	standard := make([]db.Deck, 0)
+
+	for _, deck := range decks {
+		if deck.Standard && format.Standard.Validate(deck.Cards) == nil {
+			standard = append(standard, deck)
+		}
+	}
+
+	if len(standard) < 1 {
+		logrus.Warnf("There are no standard decks for the bot to choose from in match %s", b.match.ID)
+		b.match.Do(func() { b.match.Chat("Server", "The computer could not find a deck to play with") })
+		return
+	}
+
+	b.match.Do(func() {
+		if err := b.match.ChooseDeck(b.player, standard[b.match.Rand().Intn(len(standard))]); err != nil {
+			logrus.Warnf("The bot could not choose a deck in match %s. %v", b.match.ID, err)
+		}
+	})
+
+}
+
+// respond answers the current prompt. If the match rejected the previous selection
+// a simpler one is tried, until the bot falls back to the answer the match would make
+// on its behalf, so the match never waits for it
+func (b *Bot) respond() {
+
+	b.mutex.Lock()
+	prompt := b.prompt
+	attempts := b.attempts
+	b.mutex.Unlock()
+
+	if prompt == nil {
+		return
+	}
+
+	cards, min, max, cancellable := promptDetails(prompt)
+
+	var action match.PlayerAction
+
+	switch attempts {
+	case 0:
+		action = b.choose(prompt, cards, min, max, cancellable)
+	case 1:
+		action = match.PlayerAction{Cards: firstIDs(cards, min)}
+	case 2:
+		action = match.PlayerAction{Cards: firstIDs(cards, max)}
+	case 3:
+		action = match.PlayerAction{Cancel: true}
+	default:
+		logrus.Warnf("Bot in match %s was unable to answer the prompt: %s", b.match.ID, promptText(prompt))
+		action = b.match.DefaultAction(b.player.Player)
+	}
+
+	// Send is called from the loop of the match, which handles the response
+	// once it is done sending the prompt
+	go b.player.Player.Respond(action)
+
+}
+
+// choose makes a selection for the prompt based on what the prompt is for
+func (b *Bot) choose(prompt interface{}, ids []string, min int, max int, cancellable bool) match.PlayerAction {
+
+	cards := b.findCards(ids)
+
+	if len(cards) < 1 {
+		return match.PlayerAction{Cards: firstIDs(ids, min)}
+	}
+
+	me := b.player.Player
+	opponent := b.match.Opponent(me)
+
+	// mana payment, the match suggests a legal payment for the card being played
+	if msg, ok := prompt.(*server.ActionMessage); ok && len(msg.Suggested) > 0 {
+		return match.PlayerAction{Cards: msg.Suggested}
+	}
+
+	// the creature the bot decided to attack
+	if b.target != "" && contains(ids, b.target) {
+		return match.PlayerAction{Cards: []string{b.target}}
+	}
+
+	// shields to break
+	if all(cards, func(c *match.Card) bool { return c.Player == opponent && c.Zone == match.SHIELDZONE }) {
+		return match.PlayerAction{Cards: firstIDs(ids, min)}
+	}
+
+	// blockers, when the opponent is attacking
+	if !b.match.IsPlayerTurn(me) && strings.Contains(promptText(prompt), "block") {
+		return b.chooseBlocker(cards, cancellable)
+	}
+
+	// shield triggers are used whenever possible
+	if len(cards) == 1 && cards[0].Zone == match.HAND && strings.Contains(promptText(prompt), "Shield trigger") {
+		return match.PlayerAction{Cards: ids}
+	}
+
+	n := min
+	if n < 1 {
+		n = 1
+	}
+	if n > max {
+		n = max
+	}
+
+	if n < 1 {
+		return match.PlayerAction{Cancel: true}
+	}
+
+	// The bot does not know what the effect of the selection is, so it assumes that
+	// taking the opponent's cards and getting back its own cards from the graveyard or
+	// deck is good for it, and that it should give up as little as possible otherwise
+	sort.SliceStable(cards, func(i, j int) bool {
+		return b.value(cards[i]) > b.value(cards[j])
+	})
+
+	result := make([]string, 0)
+	for i := 0; i < n && i < len(cards); i++ {
+		result = append(result, cards[i].ID)
+	}
+
+	return match.PlayerAction{Cards: result}
+
+}
+
+// value returns how much the bot wants to select the card in a prompt
+func (b *Bot) value(card *match.Card) int {
+
+	if card.Player != b.player.Player {
+		return 1000 + card.ManaCost*10 + b.match.GetPower(card, false)/1000
+	}
+
+	if card.Zone == match.GRAVEYARD || card.Zone == match.DECK {
+		return 500 + card.ManaCost
+	}
+
+	return -card.ManaCost
+
+}
+
+// chooseBlocker blocks with the weakest creature that wins the battle. If none of them
+// would win, the attack is only blocked when the bot is about to lose the game
+func (b *Bot) chooseBlocker(blockers []*match.Card, cancellable bool) match.PlayerAction {
+
+	me := b.player.Player
+	opponent := b.match.Opponent(me)
+
+	attackerPower := 0
+
+	if creatures, err := opponent.Container(match.BATTLEZONE); err == nil {
+		for _, c := range creatures {
+			if c.Tapped {
+				if power := b.match.GetPower(c, true); power > attackerPower {
+					attackerPower = power
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(blockers, func(i, j int) bool {
+		return b.match.GetPower(blockers[i], false) < b.match.GetPower(blockers[j], false)
+	})
+
+	for _, blocker := range blockers {
+		if b.match.GetPower(blocker, false) > attackerPower {
+			return match.PlayerAction{Cards: []string{blocker.ID}}
+		}
+	}
+
+	shields, err := me.Container(match.SHIELDZONE)
+
+	if !cancellable || (err == nil && len(shields) < 1) {
+		return match.PlayerAction{Cards: []string{blockers[0].ID}}
+	}
+
+	return match.PlayerAction{Cancel: true}
+
+}
+
+// TakeTurn charges mana, plays the cards the bot can afford and attacks. The bot decides
+// and makes each move in the loop of the match, and waits outside of it in between
+func (b *Bot) TakeTurn() {
+
+	b.charge()
+	b.summon()
+	b.attack()
+
+}
+
+// charge puts the card that is least useful right now into the manazone
+func (b *Bot) charge() {
+
+	var card *match.Card
+
+	b.match.Do(func() { card = b.chooseCharge() })
+
+	if card == nil || !b.wait() {
+		return
+	}
+
+	b.match.Do(func() { b.match.ChargeMana(b.player, card.ID) })
+
+}
+
+// chooseCharge returns the card to charge, preferring civilizations the bot does not
+// have mana of yet, or nil if the bot can't charge mana
+func (b *Bot) chooseCharge() *match.Card {
+
+	p := b.player.Player
+
+	hand := b.match.LegalActions(p).Charge
+
+	if len(hand) < 1 {
+		return nil
+	}
+
+	mana, err := p.Container(match.MANAZONE)
+	if err != nil {
+		return nil
+	}
+
+	civs := make(map[string]bool)
+	for _, c := range mana {
+		for _, civ := range c.Civilizations() {
+			civs[civ] = true
+		}
+	}
+
+	var best *match.Card
+	bestScore := 0
+
+	for _, c := range hand {
+
+		score := c.ManaCost
+		for _, civ := range c.Civilizations() {
+			if !civs[civ] {
+				score += 3
+				break
+			}
+		}
+
+		if best == nil || score > bestScore {
+			best = c
+			bestScore = score
+		}
+
+	}
+
+	return best
+
+}
+
+// summon plays the most expensive card the bot can afford until no card can be played anymore
+func (b *Bot) summon() {
+
+	p := b.player.Player
+	failed := make(map[string]bool)
+
+	for i := 0; i < 20; i++ {
+
+		var card *match.Card
+
+		b.match.Do(func() {
+
+			playable := make([]*match.Card, 0)
+
+			for _, c := range b.match.LegalActions(p).Play {
+				if !failed[c.ID] {
+					playable = append(playable, c)
+				}
+			}
+
+			if len(playable) < 1 {
+				return
+			}
+
+			sort.SliceStable(playable, func(i, j int) bool {
+				return playable[i].ManaCost > playable[j].ManaCost
+			})
+
+			card = playable[0]
+
+		})
+
+		if card == nil || !b.wait() {
+			return
+		}
+
+		b.match.Do(func() {
+
+			b.match.PlayCard(b.player, card.ID)
+
+			if p.HasCard(match.HAND, card.ID) {
+				failed[card.ID] = true
+			}
+
+		})
+
+	}
+
+}
+
+// attack attacks with every creature that is able to, a creature only attacks if
+// it can't be blocked by a stronger creature. Tapped creatures it can beat are attacked
+// first, unless the opponent has no shields left
+func (b *Bot) attack() {
+
+	attacked := make(map[string]bool)
+
+	for i := 0; i < 20; i++ {
+
+		var attacker, target *match.Card
+		player := false
+
+		b.match.Do(func() { attacker, target, player = b.chooseAttack(attacked) })
+
+		if attacker == nil {
+			return
+		}
+
+		if target == nil && !player {
+			continue
+		}
+
+		if !b.wait() {
+			return
+		}
+
+		b.match.Do(func() {
+
+			if target != nil {
+				b.target = target.ID
+				b.match.AttackCreature(b.player, attacker.ID)
+				b.target = ""
+				return
+			}
+
+			b.match.AttackPlayer(b.player, attacker.ID)
+
+		})
+
+	}
+
+}
+
+// chooseAttack returns the next creature to attack with and the creature to attack, or
+// whether to attack the player. Neither is set if the attacker should not attack, and
+// the attacker is nil if no creature is left to attack with
+func (b *Bot) chooseAttack(attacked map[string]bool) (*match.Card, *match.Card, bool) {
+
+	p := b.player.Player
+	opponent := b.match.Opponent(p)
+
+	var attack *match.Attack
+
+	legal := b.match.LegalActions(p)
+
+	for i, a := range legal.Attacks {
+		if !attacked[a.Card.ID] {
+			attack = &legal.Attacks[i]
+			break
+		}
+	}
+
+	if attack == nil {
+		return nil, nil, false
+	}
+
+	attacker := attack.Card
+
+	attacked[attacker.ID] = true
+
+	power := b.match.GetPower(attacker, true)
+
+	shields, err := opponent.Container(match.SHIELDZONE)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	opposing, err := opponent.Container(match.BATTLEZONE)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var target *match.Card
+	blocked := false
+
+	for _, c := range opposing {
+		if !c.Tapped && c.HasCondition(cnd.Blocker) && b.match.GetPower(c, false) >= power && !attacker.HasCondition(cnd.CantBeBlocked) {
+			blocked = true
+		}
+	}
+
+	for _, c := range attack.Creatures {
+		if b.match.GetPower(c, false) < power && (target == nil || c.ManaCost > target.ManaCost) {
+			target = c
+		}
+	}
+
+	if blocked && len(shields) > 0 {
+		return attacker, nil, false
+	}
+
+	if target != nil && (len(shields) > 0 || !attack.Player) {
+		return attacker, target, false
+	}
+
+	return attacker, nil, attack.Player
+
+}
+
+// wait pauses before the next command and returns false if the match has ended in the meantime
+func (b *Bot) wait() bool {
+
+	select {
+	case <-b.done:
+		return false
+	case <-time.After(b.Delay):
+	}
+
+	ended := true
+
+	b.match.Do(func() { ended = b.match.Ended() })
+
+	return !ended
+
+}
+
+// findCards returns the cards with the specified ids from both players
+func (b *Bot) findCards(ids []string) []*match.Card {
+
+	result := make([]*match.Card, 0)
+
+	for _, id := range ids {
+		for _, p := range []*match.Player{b.match.Player1.Player, b.match.Player2.Player} {
+			if card := find(p, id); card != nil {
+				result = append(result, card)
+				break
+			}
+		}
+	}
+
+	return result
+
+}
+
+func find(p *match.Player, id string) *match.Card {
+
+	for _, zone := range zones {
+		if card, err := p.GetCard(id, zone); err == nil {
+			return card
+		}
+	}
+
+	return nil
+
+}
+
+// promptDetails returns the card ids in the order they were sent and the requirements of the prompt
+func promptDetails(prompt interface{}) ([]string, int, int, bool) {
+
+	ids := make([]string, 0)
+
+	switch msg := prompt.(type) {
+
+	case *server.ActionMessage:
+		for _, c := range msg.Cards {
+			ids = append(ids, c.CardID)
+		}
+		return ids, msg.MinSelections, msg.MaxSelections, msg.Cancellable
+
+	case *server.MultipartActionMessage:
+		keys := make([]string, 0)
+		for key := range msg.Cards {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, c := range msg.Cards[key] {
+				ids = append(ids, c.CardID)
+			}
+		}
+		return ids, msg.MinSelections, msg.MaxSelections, msg.Cancellable
+
+	}
+
+	return ids, 0, 0, true
+
+}
+
+func promptText(prompt interface{}) string {
+
+	switch msg := prompt.(type) {
+	case *server.ActionMessage:
+		return msg.Text
+	case *server.MultipartActionMessage:
+		return msg.Text
+	}
+
+	return ""
+
+}
+
+func firstIDs(ids []string, n int) []string {
+
+	if n > len(ids) {
+		n = len(ids)
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return ids[:n]
+
+}
+
+func all(cards []*match.Card, f func(*match.Card) bool) bool {
+
+	for _, c := range cards {
+		if !f(c) {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+func contains(ids []string, id string) bool {
+
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+
+	return false
+
+}
@@ -0,0 +1,149 @@
+package bot_test
+
+import (
+	"duel-masters/game/bot"
+	"duel-masters/game/cards"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// deck returns a deck of 40 cards from the set, taking every card of the set in turn
+func deck(set string) []string {
+
+	uids := make([]string, 0)
+
+	for uid := range *cards.Sets[set] {
+		uids = append(uids, uid)
+	}
+
+	sort.Strings(uids)
+
+	result := make([]string, 0)
+
+	for i := 0; i < 40; i++ {
+		result = append(result, uids[i%len(uids)])
+	}
+
+	return result
+
+}
+
+// TestBotsPlayMatch lets two bots play a match with the cards of each set until one of them wins
+func TestBotsPlayMatch(t *testing.T) {
+
+	matchtest.Register()
+
+	sets := make([]string, 0)
+
+	for set := range cards.Sets {
+		sets = append(sets, set)
+	}
+
+	sort.Strings(sets)
+
+	for i, set := range sets {
+
+		t.Run(set, func(t *testing.T) {
+
+			m := match.NewHeadless("bots")
+			m.SetSeed(int64(i + 1))
+
+			b1 := bot.New()
+			b1.Delay = 0
+
+			b2 := bot.New()
+			b2.Delay = 0
+
+			p1, err := m.AddPlayer("bot1", "bot1", b1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p2, err := m.AddPlayer("bot2", "bot2", b2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p1.Player.CreateDeck(deck(set))
+			p2.Player.CreateDeck(deck(set))
+
+			m.Do(m.Start)
+
+			select {
+			case <-b1.Done():
+			case <-time.After(30 * time.Second):
+				m.Dispose()
+				t.Fatalf("The match with %s cards did not end", set)
+			}
+
+		})
+
+	}
+
+}
+
+// TestBotFallsBackToDefaultAction hands the bot a prompt whose requirements it can't know,
+// so that every selection it comes up with is rejected
+func TestBotFallsBackToDefaultAction(t *testing.T) {
+
+	matchtest.Register()
+
+	m := match.NewHeadless("bot")
+	defer m.Dispose()
+
+	b := bot.New()
+	b.Delay = 0
+
+	p, _ := m.AddPlayer("bot", "bot", b)
+	m.AddPlayer("p2", "p2", match.NewScriptedController(nil, nil))
+
+	p.Player.CreateDeck(deck("dm-01"))
+
+	var expected, answer match.PlayerAction
+	rejected := 0
+
+	done := make(chan struct{})
+
+	go m.Do(func() {
+
+		defer close(done)
+
+		cards, _ := p.Player.Container(match.DECK)
+
+		m.NewAction(p.Player, cards[:3], 1, 1, "Select a card for an effect the computer does not know", false)
+
+		expected = m.DefaultAction(p.Player)
+
+		for {
+
+			answer = p.Player.WaitForAction()
+
+			// the bot tries 4 different selections before giving up
+			if rejected == 4 {
+				break
+			}
+
+			rejected++
+			m.DefaultActionWarning(p.Player)
+
+		}
+
+		m.CloseAction(p.Player)
+
+	})
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected the bot to answer the prompt")
+	}
+
+	if !reflect.DeepEqual(answer, expected) || answer.Cancel || len(answer.Cards) != 1 {
+		t.Errorf("Expected the bot to fall back to the default action %+v after %v rejections, got %+v", expected, rejected, answer)
+	}
+
+}
@@ -0,0 +1,71 @@
+package cards_test
+
+import (
+	"duel-masters/game/cards"
+	"duel-masters/game/civ"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+var civilizations = map[string]bool{
+	civ.Fire:     true,
+	civ.Water:    true,
+	civ.Nature:   true,
+	civ.Light:    true,
+	civ.Darkness: true,
+}
+
+// TestSets creates every card in every set and checks that its basic properties are set
+func TestSets(t *testing.T) {
+
+	matchtest.Register()
+
+	seen := make(map[string]string)
+
+	for setID, set := range cards.Sets {
+
+		for uid := range *set {
+
+			if other, ok := seen[uid]; ok {
+				t.Errorf("%s: uid %s is also used in %s", setID, uid, other)
+			}
+
+			seen[uid] = setID
+
+			c, err := match.NewCard(nil, uid)
+
+			if err != nil {
+				t.Errorf("%s: %v", setID, err)
+				continue
+			}
+
+			if c.Name == "" || c.Name == "undefined_card" {
+				t.Errorf("%s: %s has no name", setID, uid)
+			}
+
+			for _, civilization := range c.Civilizations() {
+				if !civilizations[civilization] {
+					t.Errorf("%s: %s (%s) has an invalid civilization %q", setID, c.Name, uid, civilization)
+				}
+			}
+
+			if c.ManaCost < 1 {
+				t.Errorf("%s: %s (%s) has an invalid mana cost %v", setID, c.Name, uid, c.ManaCost)
+			}
+
+			if len(c.ManaRequirement) < 1 {
+				t.Errorf("%s: %s (%s) has no mana requirement", setID, c.Name, uid)
+			}
+
+			for _, requirement := range c.ManaRequirement {
+				if !civilizations[requirement] {
+					t.Errorf("%s: %s (%s) has an invalid mana requirement %q", setID, c.Name, uid, requirement)
+				}
+			}
+
+		}
+
+	}
+
+}
@@ -66,7 +66,7 @@ func bolshackSpecial(card *match.Card) {
 	power := 0
 
 	for _, graveyardCard := range graveyard {
-		if graveyardCard.Civ == civ.Fire {
+		if graveyardCard.HasCiv(civ.Fire) {
 			power += 1000
 		}
 	}
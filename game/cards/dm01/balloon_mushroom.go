@@ -17,45 +17,37 @@ func PoisonousMushroom(c *match.Card) {
 	c.ManaCost = 2
 	c.ManaRequirement = []string{civ.Nature}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		hand, err := card.Player.Container(match.HAND)
 
-			if event.CardID == card.ID && event.To == match.BATTLEZONE {
-
-				hand, err := card.Player.Container(match.HAND)
-
-				if err != nil {
-					return
-				}
-
-				ctx.Match.NewAction(card.Player, hand, 1, 1, "Select 1 card from your hand that will be sent to your manazone. Choose close to cancel.", true)
-
-				defer ctx.Match.CloseAction(card.Player)
+		if err != nil {
+			return
+		}
 
-				for {
+		ctx.Match.NewAction(card.Player, hand, 1, 1, "Select 1 card from your hand that will be sent to your manazone. Choose close to cancel.", true)
 
-					action := <-card.Player.Action
+		defer ctx.Match.CloseAction(card.Player)
 
-					if action.Cancel {
-						break
-					}
+		for {
 
-					if len(action.Cards) != 1 || !match.AssertCardsIn(hand, action.Cards...) {
-						ctx.Match.DefaultActionWarning(card.Player)
-						continue
-					}
+			action := card.Player.WaitForAction()
 
-					card.Player.MoveCard(action.Cards[0], match.HAND, match.MANAZONE)
+			if action.Cancel {
+				break
+			}
 
-					break
+			if len(action.Cards) != 1 || !match.AssertCardsIn(hand, action.Cards...) {
+				ctx.Match.DefaultActionWarning(card.Player)
+				continue
+			}
 
-				}
+			card.Player.MoveCard(action.Cards[0], match.HAND, match.MANAZONE)
 
-			}
+			break
 
 		}
 
-	})
+	}))
 
 }
@@ -32,59 +32,51 @@ func StormShell(c *match.Card) {
 	c.ManaCost = 7
 	c.ManaRequirement = []string{civ.Nature}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		opponent := ctx.Match.Opponent(card.Player)
 
-			if event.CardID != card.ID || event.To != match.BATTLEZONE {
-				return
-			}
-
-			opponent := ctx.Match.Opponent(card.Player)
-
-			battlezone, err := opponent.Container(match.BATTLEZONE)
-
-			if err != nil {
-				return
-			}
-
-			if len(battlezone) < 1 {
-				return
-			}
+		battlezone, err := opponent.Container(match.BATTLEZONE)
 
-			ctx.Match.Wait(card.Player, "Waiting for your opponent to make an action")
+		if err != nil {
+			return
+		}
 
-			ctx.Match.NewAction(opponent, battlezone, 1, 1, "Storm Shell: Select 1 card from your battlezone that will be sent to your manazone", false)
+		if len(battlezone) < 1 {
+			return
+		}
 
-			defer func() {
-				ctx.Match.EndWait(card.Player)
-				ctx.Match.CloseAction(opponent)
-			}()
+		ctx.Match.Wait(card.Player, "Waiting for your opponent to make an action")
 
-			for {
+		ctx.Match.NewAction(opponent, battlezone, 1, 1, "Storm Shell: Select 1 card from your battlezone that will be sent to your manazone", false)
 
-				action := <-opponent.Action
+		defer func() {
+			ctx.Match.EndWait(card.Player)
+			ctx.Match.CloseAction(opponent)
+		}()
 
-				if len(action.Cards) != 1 || !match.AssertCardsIn(battlezone, action.Cards...) {
-					ctx.Match.ActionWarning(opponent, "Your selection of cards does not fulfill the requirements")
-					continue
-				}
+		for {
 
-				movedCard, err := opponent.MoveCard(action.Cards[0], match.BATTLEZONE, match.MANAZONE)
+			action := opponent.WaitForAction()
 
-				if err != nil {
-					break
-				}
+			if len(action.Cards) != 1 || !match.AssertCardsIn(battlezone, action.Cards...) {
+				ctx.Match.ActionWarning(opponent, "Your selection of cards does not fulfill the requirements")
+				continue
+			}
 
-				ctx.Match.Chat("Server", fmt.Sprintf("%s was moved from %s's battlezone to their manazone", movedCard.Name, opponent.Username()))
+			movedCard, err := opponent.MoveCard(action.Cards[0], match.BATTLEZONE, match.MANAZONE)
 
+			if err != nil {
 				break
-
 			}
 
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was moved from %s's battlezone to their manazone", movedCard.Name, opponent.Username()))
+
+			break
+
 		}
 
-	})
+	}))
 
 }
 
@@ -17,40 +17,32 @@ func VampireSilphy(c *match.Card) {
 	c.ManaCost = 8
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		opponent := ctx.Match.Opponent(card.Player)
 
-			if event.CardID == card.ID && event.To == match.BATTLEZONE {
-
-				opponent := ctx.Match.Opponent(card.Player)
-
-				myCreatures, err := card.Player.Container(match.BATTLEZONE)
-				if err != nil {
-					return
-				}
-
-				opponentCreatures, err := opponent.Container(match.BATTLEZONE)
-				if err != nil {
-					return
-				}
-
-				for _, creature := range myCreatures {
-					if ctx.Match.GetPower(creature, false) <= 3000 {
-						ctx.Match.Destroy(creature, card, match.DestroyedByMiscAbility)
-					}
-				}
+		myCreatures, err := card.Player.Container(match.BATTLEZONE)
+		if err != nil {
+			return
+		}
 
-				for _, creature := range opponentCreatures {
-					if ctx.Match.GetPower(creature, false) <= 3000 {
-						ctx.Match.Destroy(creature, card, match.DestroyedByMiscAbility)
-					}
-				}
+		opponentCreatures, err := opponent.Container(match.BATTLEZONE)
+		if err != nil {
+			return
+		}
 
+		for _, creature := range myCreatures {
+			if ctx.Match.GetPower(creature, false) <= 3000 {
+				ctx.Match.Destroy(creature, card, match.DestroyedByMiscAbility)
 			}
+		}
 
+		for _, creature := range opponentCreatures {
+			if ctx.Match.GetPower(creature, false) <= 3000 {
+				ctx.Match.Destroy(creature, card, match.DestroyedByMiscAbility)
+			}
 		}
 
-	})
+	}))
 
 }
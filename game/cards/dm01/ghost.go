@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // DarkRavenShadowOfGrief ...
@@ -33,32 +32,24 @@ func MaskedHorrorShadowOfScorn(c *match.Card) {
 	c.ManaCost = 5
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
-
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
-
-			if event.CardID == card.ID && event.To == match.BATTLEZONE {
-
-				hand, err := ctx.Match.Opponent(card.Player).Container(match.HAND)
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-				if err != nil {
-					return
-				}
+		hand, err := ctx.Match.Opponent(card.Player).Container(match.HAND)
 
-				if len(hand) < 1 {
-					return
-				}
-
-				discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
-				if err == nil {
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand", discardedCard.Name, discardedCard.Player.Username()))
-				}
+		if err != nil {
+			return
+		}
 
-			}
+		if len(hand) < 1 {
+			return
+		}
 
+		discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+		if err == nil {
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand", discardedCard.Name, discardedCard.Player.Username()))
 		}
 
-	})
+	}))
 
 }
 
@@ -46,76 +46,68 @@ func AquaSniper(c *match.Card) {
 	c.ManaCost = 8
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		cards := make(map[string][]*match.Card)
 
-			if event.CardID != card.ID || event.To != match.BATTLEZONE {
-				return
-			}
-
-			cards := make(map[string][]*match.Card)
-
-			myCards, err := card.Player.Container(match.BATTLEZONE)
+		myCards, err := card.Player.Container(match.BATTLEZONE)
 
-			if err != nil {
-				return
-			}
+		if err != nil {
+			return
+		}
 
-			opponentCards, err := ctx.Match.Opponent(card.Player).Container(match.BATTLEZONE)
+		opponentCards, err := ctx.Match.Opponent(card.Player).Container(match.BATTLEZONE)
 
-			if err != nil {
-				return
-			}
+		if err != nil {
+			return
+		}
 
-			if len(myCards) < 1 && len(opponentCards) < 1 {
-				return
-			}
+		if len(myCards) < 1 && len(opponentCards) < 1 {
+			return
+		}
 
-			cards["Your creatures"] = myCards
-			cards["Opponent's creatures"] = opponentCards
+		cards["Your creatures"] = myCards
+		cards["Opponent's creatures"] = opponentCards
 
-			ctx.Match.NewMultipartAction(card.Player, cards, 1, 2, "Choose up to 2 creatures in the battle zone and return them to their owners' hands", true)
+		ctx.Match.NewMultipartAction(card.Player, cards, 1, 2, "Choose up to 2 creatures in the battle zone and return them to their owners' hands", true)
 
-			for {
+		for {
 
-				action := <-card.Player.Action
+			action := card.Player.WaitForAction()
 
-				if action.Cancel {
-					break
-				}
-
-				if len(action.Cards) < 1 || len(action.Cards) > 2 {
-					break
-				}
+			if action.Cancel {
+				break
+			}
 
-				for _, vid := range action.Cards {
+			if len(action.Cards) < 1 || len(action.Cards) > 2 {
+				break
+			}
 
-					ref, err := c.Player.MoveCard(vid, match.BATTLEZONE, match.HAND)
+			for _, vid := range action.Cards {
 
-					if err != nil {
+				ref, err := c.Player.MoveCard(vid, match.BATTLEZONE, match.HAND)
 
-						ref, err := ctx.Match.Opponent(c.Player).MoveCard(vid, match.BATTLEZONE, match.HAND)
+				if err != nil {
 
-						if err == nil {
-							ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
-						}
+					ref, err := ctx.Match.Opponent(c.Player).MoveCard(vid, match.BATTLEZONE, match.HAND)
 
-					} else {
-						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+					if err == nil {
+						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 					}
 
+				} else {
+					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 				}
 
-				break
-
 			}
 
-			ctx.Match.CloseAction(c.Player)
+			break
 
 		}
 
-	})
+		ctx.Match.CloseAction(c.Player)
+
+	}))
 
 }
 
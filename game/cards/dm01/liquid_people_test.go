@@ -0,0 +1,28 @@
+package dm01_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const (
+	aquaHulcus  = "57eeb3c3-2561-4841-a381-2e50d17533d1"
+	burningMane = "1d72eb3e-5185-449a-a16f-391bd2338343"
+)
+
+func TestAquaHulcusDrawsWhenSummoned(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{aquaHulcus}, Manazone: []string{aquaHulcus, aquaHulcus, aquaHulcus}, Deck: []string{burningMane}},
+		matchtest.Board{},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(3))
+	h.P1.Play(aquaHulcus)
+
+	h.P1.AssertZone(match.BATTLEZONE, aquaHulcus)
+	h.P1.AssertZone(match.HAND, burningMane)
+	h.P1.AssertCount(match.DECK, 0)
+
+}
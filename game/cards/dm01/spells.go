@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // AuraBlast ...
@@ -295,7 +294,7 @@ func GhostTouch(c *match.Card) {
 				return
 			}
 
-			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
 			if err == nil {
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand", discardedCard.Name, discardedCard.Player.Username()))
 			}
@@ -564,7 +563,7 @@ func SpiralGate(c *match.Card) {
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if len(action.Cards) != 1 {
 					ctx.Match.DefaultActionWarning(card.Player)
@@ -580,11 +579,11 @@ func SpiralGate(c *match.Card) {
 						ref, err := ctx.Match.Opponent(c.Player).MoveCard(vid, match.BATTLEZONE, match.HAND)
 
 						if err == nil {
-							ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+							ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 						}
 
 					} else {
-						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 					}
 
 				}
@@ -638,7 +637,7 @@ func Teleportation(c *match.Card) {
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if len(action.Cards) < 1 || len(action.Cards) > 2 {
 					ctx.Match.DefaultActionWarning(card.Player)
@@ -654,11 +653,11 @@ func Teleportation(c *match.Card) {
 						ref, err := ctx.Match.Opponent(c.Player).MoveCard(vid, match.BATTLEZONE, match.HAND)
 
 						if err == nil {
-							ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+							ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 						}
 
 					} else {
-						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 					}
 
 				}
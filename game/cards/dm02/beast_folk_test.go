@@ -0,0 +1,39 @@
+package dm02_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const silverAxe = "94e5d2a3-d8b2-4fce-9899-61adb063dc60"
+
+func TestSilverAxeNoAttackableCreatures(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{silverAxe}, Deck: []string{aquaHulcus, aquaHulcus}},
+		matchtest.Board{Battlezone: []string{phantomFish}},
+	)
+
+	h.P1.AttackCreature(silverAxe)
+
+	h.P1.AssertCount(match.MANAZONE, 0)
+	h.P1.AssertCount(match.DECK, 2)
+	h.AssertTapped(h.P1.Card(match.BATTLEZONE, silverAxe), false)
+
+}
+
+func TestSilverAxeAddsManaWhenAttacking(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{silverAxe}, Deck: []string{aquaHulcus}},
+		matchtest.Board{Shieldzone: []string{aquaHulcus}},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(1))
+	h.P1.AttackPlayer(silverAxe)
+
+	h.P1.AssertZone(match.MANAZONE, aquaHulcus)
+	h.P1.AssertCount(match.DECK, 0)
+
+}
@@ -31,7 +31,7 @@ func StainedGlass(c *match.Card) {
 				1,
 				1,
 				true,
-				func(x *match.Card) bool { return x.Civ == civ.Fire || x.Civ == civ.Nature },
+				func(x *match.Card) bool { return x.HasCiv(civ.Fire) || x.HasCiv(civ.Nature) },
 			).Map(func(x *match.Card) {
 				x.Player.MoveCard(x.ID, match.BATTLEZONE, match.HAND)
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was sent to %s's hand from the battle zone by Stained Glass", x.Name, x.Player.Username()))
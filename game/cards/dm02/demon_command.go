@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // DarkTitanMaginn ...
@@ -51,7 +50,7 @@ func dtmSpecial(card *match.Card, ctx *match.Context, cardID string) {
 			return
 		}
 
-		discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+		discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
 		if err == nil {
 			ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand by %s", discardedCard.Name, discardedCard.Player.Username(), card.Name))
 		}
@@ -33,7 +33,7 @@ func PhalEegaDawnGuardian(c *match.Card) {
 	c.ManaCost = 5
 	c.ManaRequirement = []string{civ.Light}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.SelectFilter(
 			card.Player,
@@ -81,7 +81,7 @@ func LarbaGeerTheImmaculate(c *match.Card) {
 	c.ManaCost = 3
 	c.ManaRequirement = []string{civ.Light}
 
-	c.Use(fx.Creature, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		ctx.ScheduleAfter(func() {
 
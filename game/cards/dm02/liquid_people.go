@@ -33,7 +33,7 @@ func CrystalPaladin(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.FindFilter(
 			card.Player,
@@ -67,7 +67,7 @@ func AquaBouncer(c *match.Card) {
 	c.ManaCost = 6
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, fx.Blocker, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Blocker, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		cards := make(map[string][]*match.Card)
 
@@ -51,7 +51,7 @@ func EngineerKipo(c *match.Card) {
 
 					for {
 
-						action := <-p.Action
+						action := p.WaitForAction()
 
 						if len(action.Cards) != toSelect || !match.AssertCardsIn(manazone, action.Cards...) {
 							ctx.Match.DefaultActionWarning(p)
@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // ChaosWorm ...
@@ -19,7 +18,7 @@ func ChaosWorm(c *match.Card) {
 	c.ManaCost = 5
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.Select(
 			card.Player,
@@ -69,7 +68,7 @@ func HorridWorm(c *match.Card) {
 			return
 		}
 
-		discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+		discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
 		if err == nil {
 			ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand by Horrid Worm", discardedCard.Name, discardedCard.Player.Username()))
 		}
@@ -87,7 +86,7 @@ func PoisonWorm(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.SelectFilter(
 			card.Player,
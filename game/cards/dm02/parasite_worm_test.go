@@ -0,0 +1,62 @@
+package dm02_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const (
+	horridWorm  = "733a4f35-7470-40e3-9cd7-479aa965bfbb"
+	aquaHulcus  = "57eeb3c3-2561-4841-a381-2e50d17533d1"
+	phantomFish = "4b021e6f-39cf-401e-89cf-f164f7c0a797"
+)
+
+func TestHorridWormDiscardsWhenAttacking(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{horridWorm}},
+		matchtest.Board{Hand: []string{aquaHulcus}, Shieldzone: []string{aquaHulcus}},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(1))
+	h.P1.AttackPlayer(horridWorm)
+
+	h.P2.AssertZone(match.GRAVEYARD, aquaHulcus)
+	h.P2.AssertZone(match.HAND, aquaHulcus)
+	h.AssertTapped(h.P1.Card(match.BATTLEZONE, horridWorm), true)
+	h.AssertChat("discarded from player2's hand by Horrid Worm")
+
+}
+
+func TestHorridWormDiscardsWhenNotBlocked(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{horridWorm}},
+		matchtest.Board{Hand: []string{aquaHulcus}, Shieldzone: []string{aquaHulcus}, Battlezone: []string{phantomFish}},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(1))
+	h.P2.Respond(matchtest.Cancel())
+	h.P1.AttackPlayer(horridWorm)
+
+	h.P2.AssertZone(match.GRAVEYARD, aquaHulcus)
+	h.P2.AssertZone(match.HAND, aquaHulcus)
+	h.P2.AssertCount(match.SHIELDZONE, 0)
+
+}
+
+func TestHorridWormDiscardsBeforeBreakingShields(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{horridWorm}},
+		matchtest.Board{Shieldzone: []string{aquaHulcus}},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(1))
+	h.P1.AttackPlayer(horridWorm)
+
+	h.P2.AssertCount(match.GRAVEYARD, 0)
+	h.P2.AssertZone(match.HAND, aquaHulcus)
+
+}
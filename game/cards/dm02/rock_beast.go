@@ -82,7 +82,7 @@ func Bombersaur(c *match.Card) {
 
 					for {
 
-						action := <-p.Action
+						action := p.WaitForAction()
 
 						if len(action.Cards) != toSelect || !match.AssertCardsIn(manazone, action.Cards...) {
 							ctx.Match.DefaultActionWarning(p)
@@ -20,24 +20,14 @@ func ElfX(c *match.Card) {
 
 	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
 
-		if !ctx.Match.IsPlayerTurn(card.Player) {
+		if card.Zone != match.BATTLEZONE {
 			return
 		}
 
-		if event, ok := ctx.Event.(*match.PlayCardEvent); ok {
-
-			if card.Zone == match.BATTLEZONE && event.CardID != card.ID {
-
-				toReduce, err := ctx.Match.CurrentPlayer().Player.GetCard(event.CardID, match.HAND)
-
-				if err != nil {
-					return
-				}
-
-				if toReduce.HasCondition(cnd.Creature) {
-					toReduce.AddCondition(cnd.ReducedCost, 1, card.ID)
-				}
+		if event, ok := ctx.Event.(*match.GetManaCostEvent); ok {
 
+			if event.Card.Player == card.Player && event.Card.Zone == match.HAND && event.Card.HasCondition(cnd.Creature) {
+				event.Reduction++
 			}
 
 		}
@@ -58,24 +48,14 @@ func EssenceElf(c *match.Card) {
 
 	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
 
-		if !ctx.Match.IsPlayerTurn(card.Player) {
+		if card.Zone != match.BATTLEZONE {
 			return
 		}
 
-		if event, ok := ctx.Event.(*match.PlayCardEvent); ok {
-
-			if card.Zone == match.BATTLEZONE && event.CardID != card.ID {
-
-				toReduce, err := ctx.Match.CurrentPlayer().Player.GetCard(event.CardID, match.HAND)
-
-				if err != nil {
-					return
-				}
-
-				if toReduce.HasCondition(cnd.Spell) {
-					toReduce.AddCondition(cnd.ReducedCost, 1, card.ID)
-				}
+		if event, ok := ctx.Event.(*match.GetManaCostEvent); ok {
 
+			if event.Card.Player == card.Player && event.Card.Zone == match.HAND && event.Card.HasCondition(cnd.Spell) {
+				event.Reduction++
 			}
 
 		}
@@ -0,0 +1,29 @@
+package dm02_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const elfX = "60d8c6a6-20c1-425c-9ecc-b56981a70e21"
+
+func TestElfXReducesCreatureCost(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{elfX}, Hand: []string{phantomFish}, Manazone: []string{phantomFish, phantomFish}},
+		matchtest.Board{},
+	)
+
+	fish := h.P1.Card(match.HAND, phantomFish)
+
+	if cost := h.Match.GetManaCost(fish); cost != 2 {
+		t.Errorf("Expected Phantom Fish to cost 2 with Elf-X in the battlezone, got %v", cost)
+	}
+
+	h.P1.Respond(matchtest.SelectFirst(2))
+	h.P1.Play(phantomFish)
+
+	h.P1.AssertZone(match.BATTLEZONE, elfX, phantomFish)
+
+}
@@ -75,7 +75,7 @@ func uberDragonJabahaSpecial(card *match.Card, ctx *match.Context, cardID string
 		return
 	}
 
-	if creature.Civ != civ.Fire || creature.ID == card.ID {
+	if !creature.HasCiv(civ.Fire) || creature.ID == card.ID {
 		return
 	}
 
@@ -97,7 +97,7 @@ func getFireCardsInYourBattleZone(card *match.Card) int {
 	count := 0
 
 	for _, battleZoneCard := range battleZone {
-		if battleZoneCard.Civ == civ.Fire {
+		if battleZoneCard.HasCiv(civ.Fire) {
 			count++
 		}
 	}
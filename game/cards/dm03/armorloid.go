@@ -30,7 +30,7 @@ func ArmoredWarriorQuelos(c *match.Card) {
 				1,
 				1,
 				false,
-				func(x *match.Card) bool { return x.Civ != civ.Fire },
+				func(x *match.Card) bool { return !x.HasCiv(civ.Fire) },
 			)
 
 			for _, creature := range creatures {
@@ -50,7 +50,7 @@ func ArmoredWarriorQuelos(c *match.Card) {
 				1,
 				1,
 				false,
-				func(x *match.Card) bool { return x.Civ != civ.Fire },
+				func(x *match.Card) bool { return !x.HasCiv(civ.Fire) },
 			)
 
 			for _, creature := range opponentCreatures {
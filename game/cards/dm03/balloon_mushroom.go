@@ -28,7 +28,7 @@ func Psyshroom(c *match.Card) {
 			0,
 			1,
 			true,
-			func(x *match.Card) bool { return x.Civ == civ.Nature },
+			func(x *match.Card) bool { return x.HasCiv(civ.Nature) },
 		).Map(func(x *match.Card) {
 			card.Player.MoveCard(x.ID, match.GRAVEYARD, match.MANAZONE)
 		})
@@ -37,7 +37,7 @@ func getLightCardsInYourBattleZone(card *match.Card) int {
 	count := 0
 
 	for _, battleZoneCard := range battleZone {
-		if battleZoneCard.Civ == civ.Light {
+		if battleZoneCard.HasCiv(civ.Light) {
 			count++
 		}
 	}
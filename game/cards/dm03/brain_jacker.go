@@ -28,7 +28,7 @@ func BonePiercer(c *match.Card) {
 
 				for _, crd := range cards {
 					card.Player.MoveCard(crd.ID, match.MANAZONE, match.HAND)
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their mana zone", crd.Name, ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their mana zone", crd.Name, ctx.Match.PlayerRef(card.Player).Username))
 				}
 
 			}
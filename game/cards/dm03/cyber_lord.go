@@ -18,52 +18,44 @@ func Emeral(c *match.Card) {
 	c.ManaCost = 2
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		nrShields, err := card.Player.Container(match.SHIELDZONE)
 
-			if event.CardID == card.ID && event.To == match.BATTLEZONE {
-
-				nrShields, err := card.Player.Container(match.SHIELDZONE)
-
-				if err != nil {
-					return
-				}
-
-				if len(nrShields) < 1 {
-					return
-				}
-
-				toShield := match.Search(card.Player, ctx.Match, card.Player, match.HAND, "Emeral: You may select 1 card from your hand and put it into the shield zone", 0, 1, true)
+		if err != nil {
+			return
+		}
 
-				if len(toShield) < 1 {
-					return
-				}
+		if len(nrShields) < 1 {
+			return
+		}
 
-				toHand := fx.SelectBackside(
-					card.Player,
-					ctx.Match,
-					card.Player,
-					match.SHIELDZONE,
-					"Emeral: Select 1 of your shields that will be moved to your hand",
-					1,
-					1,
-					false,
-				)
+		toShield := match.Search(card.Player, ctx.Match, card.Player, match.HAND, "Emeral: You may select 1 card from your hand and put it into the shield zone", 0, 1, true)
 
-				for _, card := range toShield {
-					card.Player.MoveCard(card.ID, match.HAND, match.SHIELDZONE)
-				}
+		if len(toShield) < 1 {
+			return
+		}
 
-				for _, card := range toHand {
-					card.Player.MoveCard(card.ID, match.SHIELDZONE, match.HAND)
-				}
+		toHand := fx.SelectBackside(
+			card.Player,
+			ctx.Match,
+			card.Player,
+			match.SHIELDZONE,
+			"Emeral: Select 1 of your shields that will be moved to your hand",
+			1,
+			1,
+			false,
+		)
 
-			}
+		for _, card := range toShield {
+			card.Player.MoveCard(card.ID, match.HAND, match.SHIELDZONE)
+		}
 
+		for _, card := range toHand {
+			card.Player.MoveCard(card.ID, match.SHIELDZONE, match.HAND)
 		}
 
-	})
+	}))
 
 }
 
@@ -77,7 +69,7 @@ func Shtra(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.Select(
 			card.Player,
@@ -36,11 +36,11 @@ func GamilKnightOfHatred(c *match.Card) {
 	c.Use(fx.Creature, fx.When(fx.Attacking, func(card *match.Card, ctx *match.Context) {
 		
 		ctx.ScheduleAfter(func() {
-			creatures := match.Filter(card.Player, ctx.Match, card.Player, match.GRAVEYARD, "Select 1 of your darkness creatures from the graveyard that will be returned to your hand", 0, 1, true, func(x *match.Card) bool { return x.HasCondition(cnd.Creature) && x.Civ == civ.Darkness })
+			creatures := match.Filter(card.Player, ctx.Match, card.Player, match.GRAVEYARD, "Select 1 of your darkness creatures from the graveyard that will be returned to your hand", 0, 1, true, func(x *match.Card) bool { return x.HasCondition(cnd.Creature) && x.HasCiv(civ.Darkness) })
 					
 			for _, creature := range creatures {
 				card.Player.MoveCard(creature.ID, match.GRAVEYARD, match.HAND)
-				ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their  graveyard", creature.Name, ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+				ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their  graveyard", creature.Name, ctx.Match.PlayerRef(card.Player).Username))
 			}
 		})
 	}))
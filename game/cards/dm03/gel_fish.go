@@ -45,7 +45,7 @@ func getWaterCardsInYourBattleZone(card *match.Card) int {
 	count := 0
 
 	for _, battleZoneCard := range battleZone {
-		if battleZoneCard.Civ == civ.Water {
+		if battleZoneCard.HasCiv(civ.Water) {
 			count++
 		}
 	}
@@ -18,7 +18,7 @@ func JackViperShadowofDoom(c *match.Card) {
 	c.ManaCost = 3
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		ctx.Match.ApplyPersistentEffect(func(card2 *match.Card, ctx2 *match.Context, exit func()) {
 
@@ -36,7 +36,7 @@ func JackViperShadowofDoom(c *match.Card) {
 			fx.FindFilter(
 				card.Player,
 				match.GRAVEYARD,
-				func(x *match.Card) bool { return x.ID == event.CardID && x.ID != card.ID && card.Civ == civ.Darkness },
+				func(x *match.Card) bool { return x.ID == event.CardID && x.ID != card.ID && card.HasCiv(civ.Darkness) },
 			).Map(func(x *match.Card) {
 				card.Player.MoveCard(x.ID, match.GRAVEYARD, match.HAND)
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from the graveyard by Jack Viper, Shadow of Doom", x.Name, x.Player.Username()))
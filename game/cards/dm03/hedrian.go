@@ -19,7 +19,7 @@ func Mudman(c *match.Card) {
 
 	c.PowerModifier = func(m *match.Match, attacking bool) int {
 
-		if match.ContainerHas(c.Player, match.MANAZONE, func(x *match.Card) bool { return x.Civ != civ.Darkness }) {
+		if match.ContainerHas(c.Player, match.MANAZONE, func(x *match.Card) bool { return !x.HasCiv(civ.Darkness) }) {
 			return 0
 		}
 
@@ -60,7 +60,7 @@ func getDarknessCardsInYourBattleZone(card *match.Card) int {
 	count := 0
 
 	for _, battleZoneCard := range battleZone {
-		if battleZoneCard.Civ == civ.Darkness {
+		if battleZoneCard.HasCiv(civ.Darkness) {
 			count++
 		}
 	}
@@ -19,7 +19,7 @@ func LenaVizierOfBrilliance(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Light}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		spells := match.Filter(card.Player, ctx.Match, card.Player, match.MANAZONE, "You may select 1 spell from your mana zone that will be sent to your hand", 0, 1, false, func(x *match.Card) bool { return x.HasCondition(cnd.Spell) })
 
@@ -53,7 +53,7 @@ func SiegBaliculaTheIntense(c *match.Card) {
 			card.Player,
 			match.BATTLEZONE,
 			func(x *match.Card) bool {
-				return (x.Civ == civ.Light && x.ID != card.ID && !x.Tapped)
+				return (x.HasCiv(civ.Light) && x.ID != card.ID && !x.Tapped)
 			},
 		)
 
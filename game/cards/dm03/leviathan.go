@@ -46,7 +46,7 @@ func KingNeptas(c *match.Card) {
 
 		for {
 
-			action := <-card.Player.Action
+			action := card.Player.WaitForAction()
 
 			if action.Cancel {
 				break
@@ -65,11 +65,11 @@ func KingNeptas(c *match.Card) {
 					ref, err := ctx.Match.Opponent(c.Player).MoveCard(vid, match.BATTLEZONE, match.HAND)
 
 					if err == nil {
-						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+						ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 					}
 
 				} else {
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Socket.User.Username))
+					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand", ref.Name, ctx.Match.PlayerRef(ref.Player).Username))
 				}
 
 			}
@@ -97,7 +97,7 @@ func KingPonitas(c *match.Card) {
 	c.Use(fx.Creature, fx.When(fx.Attacking, func(card *match.Card, ctx *match.Context) {
 
 		ctx.ScheduleAfter(func() {
-			waterCards := match.Filter(card.Player, ctx.Match, card.Player, match.DECK, "Select 1 wated card from your deck that will be shown to your opponent and sent to your hand", 1, 1, false, func(x *match.Card) bool { return x.Civ == civ.Water })
+			waterCards := match.Filter(card.Player, ctx.Match, card.Player, match.DECK, "Select 1 wated card from your deck that will be shown to your opponent and sent to your hand", 1, 1, false, func(x *match.Card) bool { return x.HasCiv(civ.Water) })
 
 			for _, waterCard := range waterCards {
 
@@ -149,7 +149,7 @@ func legendaryBynorSpecial(card *match.Card, ctx *match.Context, cardID string)
 		return
 	}
 
-	if creature.Civ != civ.Water || creature.ID == card.ID {
+	if !creature.HasCiv(civ.Water) || creature.ID == card.ID {
 		return
 	}
 
@@ -184,7 +184,7 @@ func PsychicShaper(c *match.Card) {
 
 			for _, toMove := range cards {
 
-				if toMove.Civ == civ.Water {
+				if toMove.HasCiv(civ.Water) {
 					card.Player.MoveCard(toMove.ID, match.DECK, match.HAND)
 					ctx.Match.Chat("Server", fmt.Sprintf("%s put %s into the hand from the top of their deck", card.Player.Username(), toMove.Name))
 				} else {
@@ -208,7 +208,7 @@ func EldritchPoison(c *match.Card) {
 
 		if match.AmICasted(card, ctx) {
 
-			creatures := match.Filter(card.Player, ctx.Match, card.Player, match.BATTLEZONE, "Select 1 of your darkness creatures that will be destroyed", 0, 1, true, func(x *match.Card) bool { return x.Civ == civ.Darkness })
+			creatures := match.Filter(card.Player, ctx.Match, card.Player, match.BATTLEZONE, "Select 1 of your darkness creatures that will be destroyed", 0, 1, true, func(x *match.Card) bool { return x.HasCiv(civ.Darkness) })
 
 			if len(creatures) > 0 {
 
@@ -220,7 +220,7 @@ func EldritchPoison(c *match.Card) {
 
 				for _, creature := range creatures {
 					card.Player.MoveCard(creature.ID, match.MANAZONE, match.HAND)
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their mana zone", creature.Name, ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their mana zone", creature.Name, ctx.Match.PlayerRef(card.Player).Username))
 				}
 			}
 		}
@@ -312,7 +312,7 @@ func BlazeCannon(c *match.Card) {
 
 		if match.AmICasted(card, ctx) {
 
-			if match.ContainerHas(c.Player, match.MANAZONE, func(x *match.Card) bool { return x.Civ != civ.Fire }) {
+			if match.ContainerHas(c.Player, match.MANAZONE, func(x *match.Card) bool { return !x.HasCiv(civ.Fire) }) {
 				return
 			}
 
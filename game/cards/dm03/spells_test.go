@@ -0,0 +1,25 @@
+package dm03_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const logicSphere = "96370266-e077-47c1-968f-0184bcb94227"
+
+func TestLogicSphereReturnsSpellFromMana(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{logicSphere}, Manazone: []string{logicSphere, logicSphere, logicSphere, logicSphere}},
+		matchtest.Board{},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(3), matchtest.Select(logicSphere))
+	h.P1.Play(logicSphere)
+
+	h.P1.AssertZone(match.GRAVEYARD, logicSphere)
+	h.P1.AssertZone(match.HAND, logicSphere)
+	h.P1.AssertCount(match.MANAZONE, 3)
+
+}
@@ -22,7 +22,7 @@ func SparkleFlower(c *match.Card) {
 
 		if _, ok := ctx.Event.(*match.UntapStep); ok {
 
-			if match.ContainerHas(card.Player, match.MANAZONE, func(x *match.Card) bool { return x.Civ != civ.Light }) {
+			if match.ContainerHas(card.Player, match.MANAZONE, func(x *match.Card) bool { return !x.HasCiv(civ.Light) }) {
 				card.RemoveCondition(cnd.Blocker)
 			} else {
 				card.AddCondition(cnd.Blocker, true, card.ID)
@@ -80,7 +80,7 @@ func getNatureCardsInYourBattleZone(card *match.Card) int {
 	count := 0
 
 	for _, battleZoneCard := range battleZone {
-		if battleZoneCard.Civ == civ.Nature {
+		if battleZoneCard.HasCiv(civ.Nature) {
 			count++
 		}
 	}
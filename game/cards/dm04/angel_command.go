@@ -19,12 +19,12 @@ func RimuelCloudbreakElemental(c *match.Card) {
 	c.ManaCost = 8
 	c.ManaRequirement = []string{civ.Light}
 
-	c.Use(fx.Creature, fx.Doublebreaker, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Doublebreaker, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		lightMana := len(fx.FindFilter(
 			card.Player,
 			match.MANAZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Light && !x.Tapped },
+			func(x *match.Card) bool { return x.HasCiv(civ.Light) && !x.Tapped },
 		))
 
 		nrCreaturesOpp := len(fx.FindFilter(
@@ -73,7 +73,7 @@ func AlcadeiasLordOfSpirits(c *match.Card) {
 
 		if event, ok := ctx.Event.(*match.ShieldTriggerEvent); ok {
 
-			if event.Card.Civ != civ.Light && event.Card.HasCondition(cnd.Spell) {
+			if !event.Card.HasCiv(civ.Light) && event.Card.HasCondition(cnd.Spell) {
 				ctx.InterruptFlow()
 			}
 
@@ -88,7 +88,7 @@ func AlcadeiasLordOfSpirits(c *match.Card) {
 			if err != nil || !playedCard.HasCondition(cnd.Spell) {
 				return
 			}
-			if playedCard.Civ != civ.Light {
+			if !playedCard.HasCiv(civ.Light) {
 				ctx.Match.WarnPlayer(ctx.Match.Opponent(card.Player), "Only light spells may be cast while Alcadeias, Lord of Spirits is in the battle zone")
 				ctx.InterruptFlow()
 			}
@@ -119,7 +119,7 @@ func AerisFlightElemental(c *match.Card) {
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
 			func(x *match.Card) bool {
-				return x.Civ == civ.Darkness && x.Tapped == false
+				return x.HasCiv(civ.Darkness) && x.Tapped == false
 			},
 		).Map(func(x *match.Card) {
 			// don't add if already in the list of attackable creatures
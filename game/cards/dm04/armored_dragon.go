@@ -25,7 +25,7 @@ func GalklifeDragon(c *match.Card) {
 			fx.FindFilter(
 				card.Player,
 				match.BATTLEZONE,
-				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) <= 4000 && x.Civ == civ.Light },
+				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) <= 4000 && x.HasCiv(civ.Light) },
 			).Map(func(x *match.Card) {
 				ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Galklife Dragon", x.Name))
@@ -34,7 +34,7 @@ func GalklifeDragon(c *match.Card) {
 			fx.FindFilter(
 				ctx.Match.Opponent(card.Player),
 				match.BATTLEZONE,
-				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) <= 4000 && x.Civ == civ.Light },
+				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) <= 4000 && x.HasCiv(civ.Light) },
 			).Map(func(x *match.Card) {
 				ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Galklife Dragon", x.Name))
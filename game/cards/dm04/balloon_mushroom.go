@@ -2,7 +2,6 @@ package dm04
 
 import (
 	"duel-masters/game/civ"
-	"duel-masters/game/cnd"
 	"duel-masters/game/family"
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
@@ -24,21 +23,12 @@ func DewMushroom(c *match.Card) {
 			return
 		}
 
-		if event, ok := ctx.Event.(*match.PlayCardEvent); ok {
-			
-			p := ctx.Match.CurrentPlayer()
+		if event, ok := ctx.Event.(*match.GetManaCostEvent); ok {
 
-			playedCard, err := p.Player.GetCard(event.CardID, match.HAND)
-
-			if err != nil {
-				return
-			}
-
-			if playedCard.Civ != civ.Darkness {
-				return
+			if event.Card.Zone == match.HAND && event.Card.HasCiv(civ.Darkness) {
+				event.Increase += 1
 			}
 
-			playedCard.AddUniqueSourceCondition(cnd.IncreasedCost, 1, card.ID)
 		}
 	})
 
@@ -20,10 +20,10 @@ func PurplePiercer(c *match.Card) {
 	c.Use(
 		fx.Creature,
 		fx.CantBeBlockedIf(func(blocker *match.Card) bool {
-			return blocker.Civ == civ.Light
+			return blocker.HasCiv(civ.Light)
 		}),
 		fx.CantBeAttackedIf(func(attacker *match.Card) bool {
-			return attacker.Civ == civ.Light
+			return attacker.HasCiv(civ.Light)
 		}),
 	)
 
@@ -25,7 +25,7 @@ func Gigabolver(c *match.Card) {
 
 		if event, ok := ctx.Event.(*match.ShieldTriggerEvent); ok {
 
-			if event.Card.Civ == civ.Light {
+			if event.Card.HasCiv(civ.Light) {
 				ctx.InterruptFlow()
 			}
 
@@ -18,13 +18,13 @@ func Marinomancer(c *match.Card) {
 	c.ManaCost = 5
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned,  func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned,  func(card *match.Card, ctx *match.Context) {
 
 		cards := card.Player.PeekDeck(3)
 
 		for _, toMove := range cards {
 
-			if toMove.Civ == civ.Light || toMove.Civ == civ.Darkness {
+			if toMove.HasCiv(civ.Light) || toMove.HasCiv(civ.Darkness) {
 				card.Player.MoveCard(toMove.ID, match.DECK, match.HAND)
 				ctx.Match.Chat("Server", fmt.Sprintf("%s put %s into the hand from the top of their deck", card.Player.Username(), toMove.Name))
 			} else {
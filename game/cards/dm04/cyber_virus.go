@@ -17,7 +17,7 @@ func AstralWarper(c *match.Card) {
 	c.ManaCost = 6
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 		
 		//TODO: let the player select between 0 and 3
 		card.Player.DrawCards(3)
@@ -42,7 +42,7 @@ func KeeperOfTheSunlitAbyss(c *match.Card) {
 
 		if event, ok := ctx.Event.(*match.GetPowerEvent); ok {
 			
-			if event.Card.Civ == civ.Light || event.Card.Civ == civ.Darkness {
+			if event.Card.HasCiv(civ.Light) || event.Card.HasCiv(civ.Darkness) {
 				event.Power += 1000
 			}
 		}
@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // BallomMasterOfDeath ...
@@ -19,12 +18,12 @@ func BallomMasterOfDeath(c *match.Card) {
 	c.ManaCost = 8
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.Doublebreaker, fx.Evolution, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Doublebreaker, fx.Evolution, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.FindFilter(
 			card.Player,
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ != civ.Darkness },
+			func(x *match.Card) bool { return !x.HasCiv(civ.Darkness) },
 		).Map(func(x *match.Card) {
 			ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
 			ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Ballom, Master of Death", x.Name))
@@ -33,7 +32,7 @@ func BallomMasterOfDeath(c *match.Card) {
 		fx.FindFilter(
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ != civ.Darkness },
+			func(x *match.Card) bool { return !x.HasCiv(civ.Darkness) },
 		).Map(func(x *match.Card) {
 			ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
 			ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Ballom, Master of Death", x.Name))
@@ -52,12 +51,12 @@ func TroxGeneralOfDestruction(c *match.Card) {
 	c.ManaCost = 7
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.Doublebreaker, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Doublebreaker, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		nrDarkCards := len(fx.FindFilter(
 			card.Player,
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Darkness && x.ID != card.ID },
+			func(x *match.Card) bool { return x.HasCiv(civ.Darkness) && x.ID != card.ID },
 		))
 
 		hand, err := ctx.Match.Opponent(card.Player).Container(match.HAND)
@@ -67,7 +66,7 @@ func TroxGeneralOfDestruction(c *match.Card) {
 		}
 
 		for len(hand) > 0 && nrDarkCards > 0 {
-			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
 			if err == nil {
 				nrDarkCards--
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand", discardedCard.Name, discardedCard.Player.Username()))
@@ -99,7 +98,7 @@ func PhotocideLordOfTheWastes(c *match.Card) {
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
 			func(x *match.Card) bool {
-				return x.Civ == civ.Light && x.Tapped == false
+				return x.HasCiv(civ.Light) && x.Tapped == false
 			},
 		).Map(func(x *match.Card) {
 			// don't add if already in the list of attackable creatures
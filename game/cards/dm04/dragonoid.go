@@ -21,7 +21,7 @@ func BlastoExplosiveSoldier(c *match.Card) {
 
 		power := 0
 
-		if match.ContainerHas(c.Player, match.BATTLEZONE, func(x *match.Card) bool { return x.Civ == civ.Darkness }) {
+		if match.ContainerHas(c.Player, match.BATTLEZONE, func(x *match.Card) bool { return x.HasCiv(civ.Darkness) }) {
 			power += 2000
 		}
 
@@ -18,7 +18,7 @@ func ShadowMoonCursedShade(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		ctx.Match.ApplyPersistentEffect(func(card2 *match.Card, ctx2 *match.Context, exit func()) {
 
@@ -49,7 +49,7 @@ func getDarknessCreatures(card *match.Card, ctx *match.Context) fx.CardCollectio
 	darknessCreatures := fx.FindFilter(
 		card.Player,
 		match.BATTLEZONE,
-		func(x *match.Card) bool { return x.Civ == civ.Darkness && x.ID != card.ID },
+		func(x *match.Card) bool { return x.HasCiv(civ.Darkness) && x.ID != card.ID },
 	)
 
 	darknessCreatures = append(darknessCreatures,
@@ -57,7 +57,7 @@ func getDarknessCreatures(card *match.Card, ctx *match.Context) fx.CardCollectio
 		fx.FindFilter(
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Darkness && x.ID != card.ID },
+			func(x *match.Card) bool { return x.HasCiv(civ.Darkness) && x.ID != card.ID },
 		)...,
 	)
 
@@ -80,21 +80,12 @@ func VolcanoSmogDeceptiveShade(c *match.Card) {
 			return
 		}
 
-		if event, ok := ctx.Event.(*match.PlayCardEvent); ok {
+		if event, ok := ctx.Event.(*match.GetManaCostEvent); ok {
 
-			p := ctx.Match.CurrentPlayer()
-
-			playedCard, err := p.Player.GetCard(event.CardID, match.HAND)
-
-			if err != nil {
-				return
-			}
-
-			if playedCard.Civ != civ.Light {
-				return
+			if event.Card.Zone == match.HAND && event.Card.HasCiv(civ.Light) {
+				event.Increase += 2
 			}
 
-			playedCard.AddUniqueSourceCondition(cnd.IncreasedCost, 2, card.ID)
 		}
 	})
 
@@ -0,0 +1,38 @@
+package dm04_test
+
+import (
+	"duel-masters/game/cnd"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const (
+	shadowMoon = "da8d62d3-47b9-450e-961c-9bcc171ff0ee"
+	boneSpider = "4d3201e8-0d9b-481e-b8e3-86cb90058e20"
+	deathSmoke = "d1703c3b-8e49-4959-8322-ae11a7ca6632"
+)
+
+func TestShadowMoonRemovesBuffsWhenDestroyed(t *testing.T) {
+
+	mana := []string{boneSpider, boneSpider, boneSpider, boneSpider}
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{shadowMoon}, Manazone: mana},
+		matchtest.Board{Hand: []string{deathSmoke}, Manazone: mana, Battlezone: []string{boneSpider}, Deck: []string{boneSpider, boneSpider}},
+	)
+
+	h.P1.Respond(matchtest.SelectFirst(4))
+	h.P1.Play(shadowMoon)
+	h.EndTurn()
+
+	spider := h.P2.Card(match.BATTLEZONE, boneSpider)
+	h.AssertCondition(spider, cnd.PowerAmplifier)
+
+	h.P2.Respond(matchtest.SelectFirst(4), matchtest.Select(shadowMoon))
+	h.P2.Play(deathSmoke)
+
+	h.P1.AssertZone(match.GRAVEYARD, shadowMoon)
+	h.AssertNoCondition(spider, cnd.PowerAmplifier)
+
+}
@@ -30,7 +30,7 @@ func AncientGiant(c *match.Card) {
 				blockers := make([]*match.Card, 0)
 
 				for _, blocker := range event.Blockers {
-					if blocker.Civ != civ.Darkness {
+					if !blocker.HasCiv(civ.Darkness) {
 						blockers = append(blockers, blocker)
 					}
 				}
@@ -52,7 +52,7 @@ func AncientGiant(c *match.Card) {
 				blockers := make([]*match.Card, 0)
 
 				for _, blocker := range event.Blockers {
-					if blocker.Civ != civ.Darkness {
+					if !blocker.HasCiv(civ.Darkness) {
 						blockers = append(blockers, blocker)
 					}
 				}
@@ -20,10 +20,10 @@ func GulanRiasSpeedGuardian(c *match.Card) {
 	c.Use(
 		fx.Creature,
 		fx.CantBeBlockedIf(func(blocker *match.Card) bool {
-			return blocker.Civ == civ.Darkness
+			return blocker.HasCiv(civ.Darkness)
 		}),
 		fx.CantBeAttackedIf(func(attacker *match.Card) bool {
-			return attacker.Civ == civ.Darkness
+			return attacker.HasCiv(civ.Darkness)
 		}),
 	)
 }
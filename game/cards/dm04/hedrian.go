@@ -6,7 +6,6 @@ import (
 	"duel-masters/game/fx"
 	"duel-masters/game/match"
 	"fmt"
-	"math/rand"
 )
 
 // Locomotiver ...
@@ -19,7 +18,7 @@ func Locomotiver(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Darkness}
 
-	c.Use(fx.Creature, fx.ShieldTrigger, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.ShieldTrigger, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		hand, err := ctx.Match.Opponent(card.Player).Container(match.HAND)
 
@@ -28,7 +27,7 @@ func Locomotiver(c *match.Card) {
 		}
 
 		if len(hand) > 0 {
-			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[rand.Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
+			discardedCard, err := ctx.Match.Opponent(card.Player).MoveCard(hand[ctx.Match.Rand().Intn(len(hand))].ID, match.HAND, match.GRAVEYARD)
 			if err == nil {
 				ctx.Match.Chat("Server", fmt.Sprintf("%s was discarded from %s's hand", discardedCard.Name, discardedCard.Player.Username()))
 			}
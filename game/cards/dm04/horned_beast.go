@@ -20,7 +20,7 @@ func NiofaHornedProtector(c *match.Card) {
 	c.ManaRequirement = []string{civ.Nature}
 
 
-	c.Use(fx.Creature, fx.Evolution, fx.Doublebreaker, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Evolution, fx.Doublebreaker, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		cards := match.Filter(
 			card.Player,
@@ -31,7 +31,7 @@ func NiofaHornedProtector(c *match.Card) {
 			0,
 			1,
 			false,
-			func(x *match.Card) bool { return x.HasCondition(cnd.Creature) && x.Civ == civ.Nature },
+			func(x *match.Card) bool { return x.HasCondition(cnd.Creature) && x.HasCiv(civ.Nature) },
 		)
 
 		for _, c := range cards {
@@ -18,23 +18,19 @@ func KingAquakamui(c *match.Card) {
 	c.ManaCost = 7
 	c.ManaRequirement = []string{civ.Water}
 
-	c.Use(fx.Creature, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
+		fx.FindFilter(
+			card.Player,
+			match.GRAVEYARD,
+			func(x *match.Card) bool { return x.Family == family.AngelCommand || x.Family == family.DemonCommand },
+		).Map(func(x *match.Card) {
 
-			if event.CardID == card.ID && event.To == match.BATTLEZONE {
+			x.Player.MoveCard(x.ID, match.GRAVEYARD, match.HAND)
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their graveyard by King Aquakamui", x.Name, card.Player.Username()))
+		})
 
-				fx.FindFilter(
-					card.Player,
-					match.GRAVEYARD,
-					func(x *match.Card) bool { return x.Family == family.AngelCommand || x.Family == family.DemonCommand },
-				).Map(func(x *match.Card) {
-
-					x.Player.MoveCard(x.ID, match.GRAVEYARD, match.HAND)
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved to %s's hand from their graveyard by King Aquakamui", x.Name, card.Player.Username()))
-				})
-			}
-		}
+	}), func(card *match.Card, ctx *match.Context) {
 
 		if card.Zone != match.BATTLEZONE {
 			return
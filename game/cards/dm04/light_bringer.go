@@ -17,7 +17,7 @@ func KolonTheOracle(c *match.Card) {
 	c.ManaCost = 4
 	c.ManaRequirement = []string{civ.Light}
 
-	c.Use(fx.Creature, fx.ShieldTrigger, fx.When(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+	c.Use(fx.Creature, fx.ShieldTrigger, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
 
 		fx.Select(
 			card.Player,
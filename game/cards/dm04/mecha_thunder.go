@@ -26,7 +26,7 @@ func FuReilSeekerOfStorms(c *match.Card) {
 
 		if event, ok := ctx.Event.(*match.ShieldTriggerEvent); ok {
 
-			if event.Card.Civ == civ.Darkness {
+			if event.Card.HasCiv(civ.Darkness) {
 				ctx.InterruptFlow()
 			}
 
@@ -62,7 +62,7 @@ func getLightCreatures(card *match.Card, ctx *match.Context) fx.CardCollection {
 	lightCreatures := fx.FindFilter(
 		card.Player,
 		match.BATTLEZONE,
-		func(x *match.Card) bool { return x.Civ == civ.Light && x.ID != card.ID },
+		func(x *match.Card) bool { return x.HasCiv(civ.Light) && x.ID != card.ID },
 	)
 
 	lightCreatures = append(lightCreatures,
@@ -70,7 +70,7 @@ func getLightCreatures(card *match.Card, ctx *match.Context) fx.CardCollection {
 		fx.FindFilter(
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Light && x.ID != card.ID },
+			func(x *match.Card) bool { return x.HasCiv(civ.Light) && x.ID != card.ID },
 		)...,
 	)
 
@@ -53,33 +53,26 @@ func Magmarex(c *match.Card) {
 	c.ManaCost = 5
 	c.ManaRequirement = []string{civ.Fire}
 
-	c.Use(fx.Creature, fx.ShieldTrigger, func(card *match.Card, ctx *match.Context) {
-
-		if event, ok := ctx.Event.(*match.CardMoved); ok {
-
-			if event.CardID != card.ID || event.To != match.BATTLEZONE {
-				return
-			}
-
-			fx.FindFilter(
-				card.Player,
-				match.BATTLEZONE,
-				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) == 1000 },
-			).Map(func(x *match.Card) {
-				ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
-				ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Magmarex", x.Name))
-			})
-
-			fx.FindFilter(
-				ctx.Match.Opponent(card.Player),
-				match.BATTLEZONE,
-				func(x *match.Card) bool { return ctx.Match.GetPower(x, false) == 1000 },
-			).Map(func(x *match.Card) {
-				ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
-				ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Magmarex", x.Name))
-			})
-
-		}
-	})
+	c.Use(fx.Creature, fx.ShieldTrigger, fx.Trigger(fx.Summoned, func(card *match.Card, ctx *match.Context) {
+
+		fx.FindFilter(
+			card.Player,
+			match.BATTLEZONE,
+			func(x *match.Card) bool { return ctx.Match.GetPower(x, false) == 1000 },
+		).Map(func(x *match.Card) {
+			ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Magmarex", x.Name))
+		})
+
+		fx.FindFilter(
+			ctx.Match.Opponent(card.Player),
+			match.BATTLEZONE,
+			func(x *match.Card) bool { return ctx.Match.GetPower(x, false) == 1000 },
+		).Map(func(x *match.Card) {
+			ctx.Match.Destroy(x, card, match.DestroyedByMiscAbility)
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was destroyed by Magmarex", x.Name))
+		})
+
+	}))
 
 }
\ No newline at end of file
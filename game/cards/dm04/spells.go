@@ -148,7 +148,7 @@ func SwordOfMalevolentDeath(c *match.Card) {
 		nrDarkCards := len(fx.FindFilter(
 			card.Player,
 			match.MANAZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Darkness },
+			func(x *match.Card) bool { return x.HasCiv(civ.Darkness) },
 		))
 
 		fx.Find(
@@ -174,7 +174,7 @@ func HydroHurricane(c *match.Card) {
 		nrLight := len(fx.FindFilter(
 			card.Player,
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Light },
+			func(x *match.Card) bool { return x.HasCiv(civ.Light) },
 		))
 
 		fx.Select(
@@ -194,7 +194,7 @@ func HydroHurricane(c *match.Card) {
 		nrDark := len(fx.FindFilter(
 			card.Player,
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Darkness },
+			func(x *match.Card) bool { return x.HasCiv(civ.Darkness) },
 		))
 
 		fx.Select(
@@ -244,7 +244,7 @@ func SwordOfBenevolentLife(c *match.Card) {
 		nrLightCards := len(fx.FindFilter(
 			card.Player,
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Light },
+			func(x *match.Card) bool { return x.HasCiv(civ.Light) },
 		))
 
 		fx.Find(
@@ -355,7 +355,7 @@ func SoulGulp(c *match.Card) {
 		nrLight := len(fx.FindFilter(
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ == civ.Light },
+			func(x *match.Card) bool { return x.HasCiv(civ.Light) },
 		))
 
 		ctx.Match.Wait(card.Player, "Waiting for your opponent to make an action")
@@ -438,7 +438,7 @@ func getNonLightCreatures(card *match.Card, ctx *match.Context) fx.CardCollectio
 	creatures := fx.FindFilter(
 		card.Player,
 		match.BATTLEZONE,
-		func(x *match.Card) bool { return x.Civ != civ.Light },
+		func(x *match.Card) bool { return !x.HasCiv(civ.Light) },
 	)
 
 	creatures = append(creatures,
@@ -446,7 +446,7 @@ func getNonLightCreatures(card *match.Card, ctx *match.Context) fx.CardCollectio
 		fx.FindFilter(
 			ctx.Match.Opponent(card.Player),
 			match.BATTLEZONE,
-			func(x *match.Card) bool { return x.Civ != civ.Light },
+			func(x *match.Card) bool { return !x.HasCiv(civ.Light) },
 		)...,
 	)
 
@@ -5,6 +5,7 @@ import (
 	"duel-masters/game/cards/dm02"
 	"duel-masters/game/cards/dm03"
 	"duel-masters/game/cards/dm04"
+	"duel-masters/game/format"
 	"duel-masters/game/match"
 )
 
@@ -16,6 +17,24 @@ var Sets = map[string]*map[string]match.CardConstructor{
 	"dm-04": &DM04,
 }
 
+// RegisterAll adds every card in Sets to the match and format packages
+func RegisterAll() {
+
+	for setID, set := range Sets {
+		for uid, ctor := range *set {
+
+			match.AddCard(uid, ctor)
+
+			card := &match.Card{}
+			ctor(card)
+
+			format.AddCard(uid, card.Name, setID)
+
+		}
+	}
+
+}
+
 // DM01 is a map with all the card id's in the game and corresponding CardConstructor for dm01
 var DM01 = map[string]match.CardConstructor{
 
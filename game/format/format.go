@@ -0,0 +1,121 @@
+// Package format validates decks against the rules of a format, such as the
+// number of cards in a deck and the number of copies of a card
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Card holds the information about a card that is needed to validate decks
+type Card struct {
+	UID  string
+	Name string
+	Set  string
+}
+
+var cards = make(map[string]Card)
+
+// AddCard adds a card to the cards that can be used in decks
+func AddCard(uid string, name string, set string) {
+	cards[uid] = Card{
+		UID:  uid,
+		Name: name,
+		Set:  set,
+	}
+}
+
+// Violation describes how a deck breaks one of the rules of a format
+type Violation struct {
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+	Cards   []string `json:"cards,omitempty"`
+}
+
+// Rule is a rule of a format that decks have to follow
+type Rule interface {
+	// Check returns the violations of the rule in the deck, or nil if the deck follows it
+	Check(deck []Card) []Violation
+}
+
+// Format is a set of rules that decks have to follow to be played
+type Format struct {
+	Name  string
+	Rules []Rule
+}
+
+// Standard is the format used for all decks and matches
+var Standard = &Format{
+	Name: "standard",
+	Rules: []Rule{
+		DeckSize{Min: 40, Max: 50},
+		CopyLimit{Max: 4},
+	},
+}
+
+// Error is returned for decks that are not legal in a format
+type Error struct {
+	Format     string
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+
+	messages := make([]string, 0)
+
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+
+	return fmt.Sprintf("The deck is not legal in the %s format. %s", e.Format, strings.Join(messages, ". "))
+
+}
+
+// Validate checks the deck with the card uids against every rule of the format,
+// an *Error with all violations is returned if the deck is not legal
+func (f *Format) Validate(uids []string) error {
+
+	deck := make([]Card, 0)
+	unknown := make([]string, 0)
+
+	for _, uid := range uids {
+
+		card, ok := cards[uid]
+
+		if !ok {
+			unknown = append(unknown, uid)
+			continue
+		}
+
+		deck = append(deck, card)
+
+	}
+
+	// the rules can't be checked reliably without knowing all cards
+	if len(unknown) > 0 {
+		return &Error{
+			Format: f.Name,
+			Violations: []Violation{{
+				Rule:    "unknown_cards",
+				Message: fmt.Sprintf("%v of the cards do not exist", len(unknown)),
+				Cards:   unknown,
+			}},
+		}
+	}
+
+	violations := make([]Violation, 0)
+
+	for _, rule := range f.Rules {
+		violations = append(violations, rule.Check(deck)...)
+	}
+
+	if len(violations) > 0 {
+		return &Error{
+			Format:     f.Name,
+			Violations: violations,
+		}
+	}
+
+	return nil
+
+}
@@ -0,0 +1,103 @@
+package format_test
+
+import (
+	"duel-masters/game/format"
+	"fmt"
+	"testing"
+)
+
+func init() {
+	for i := 0; i < 20; i++ {
+		set := "dm-01"
+		if i >= 10 {
+			set = "dm-02"
+		}
+		format.AddCard(fmt.Sprintf("card-%v", i), fmt.Sprintf("Card %v", i), set)
+	}
+}
+
+// deck returns a deck with the specified number of copies of the first cards
+func deck(copies int, size int) []string {
+
+	result := make([]string, 0)
+
+	for i := 0; len(result) < size; i++ {
+		for j := 0; j < copies && len(result) < size; j++ {
+			result = append(result, fmt.Sprintf("card-%v", i))
+		}
+	}
+
+	return result
+
+}
+
+// violations returns the rules that were violated by the deck
+func violations(t *testing.T, f *format.Format, cards []string) []string {
+
+	err := f.Validate(cards)
+
+	if err == nil {
+		return nil
+	}
+
+	e, ok := err.(*format.Error)
+
+	if !ok {
+		t.Fatalf("Expected a *format.Error, got %T", err)
+	}
+
+	result := make([]string, 0)
+
+	for _, v := range e.Violations {
+		result = append(result, v.Rule)
+	}
+
+	return result
+
+}
+
+func TestStandard(t *testing.T) {
+
+	if v := violations(t, format.Standard, deck(4, 40)); v != nil {
+		t.Errorf("Expected a legal deck, got %v", v)
+	}
+
+	if v := violations(t, format.Standard, deck(4, 39)); len(v) != 1 || v[0] != "deck_size" {
+		t.Errorf("Expected a deck_size violation, got %v", v)
+	}
+
+	if v := violations(t, format.Standard, deck(5, 40)); len(v) != 8 || v[0] != "copy_limit" {
+		t.Errorf("Expected 8 copy_limit violations, got %v", v)
+	}
+
+	if v := violations(t, format.Standard, append(deck(4, 39), "missing")); len(v) != 1 || v[0] != "unknown_cards" {
+		t.Errorf("Expected only an unknown_cards violation, got %v", v)
+	}
+
+}
+
+func TestRules(t *testing.T) {
+
+	f := &format.Format{
+		Name: "test",
+		Rules: []format.Rule{
+			format.Sets{Allowed: []string{"dm-01"}},
+			format.Banned{Cards: []string{"card-3"}},
+		},
+	}
+
+	if v := violations(t, f, deck(4, 16)); len(v) != 1 || v[0] != "banned" {
+		t.Errorf("Expected a banned violation, got %v", v)
+	}
+
+	if v := violations(t, f, []string{"card-1", "card-11", "card-11", "card-12"}); len(v) != 2 || v[0] != "set" || v[1] != "set" {
+		t.Errorf("Expected a set violation for each card from a different set, got %v", v)
+	}
+
+	err := f.Validate([]string{"card-3"})
+
+	if err == nil || err.Error() != "The deck is not legal in the test format. Card 3 is banned" {
+		t.Errorf("Unexpected error message %v", err)
+	}
+
+}
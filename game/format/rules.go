@@ -0,0 +1,144 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DeckSize limits the number of cards in a deck
+type DeckSize struct {
+	Min int
+	Max int
+}
+
+// Check returns a violation if the deck has too few or too many cards
+func (r DeckSize) Check(deck []Card) []Violation {
+
+	if len(deck) < r.Min || len(deck) > r.Max {
+		return []Violation{{
+			Rule:    "deck_size",
+			Message: fmt.Sprintf("The deck has %v cards, it must have between %v and %v cards", len(deck), r.Min, r.Max),
+		}}
+	}
+
+	return nil
+
+}
+
+// CopyLimit limits the number of copies of a card in a deck,
+// cards with the same name are copies even if they are from different sets
+type CopyLimit struct {
+	Max int
+}
+
+// Check returns a violation for every card with too many copies in the deck
+func (r CopyLimit) Check(deck []Card) []Violation {
+
+	copies := make(map[string][]string)
+	names := make([]string, 0)
+
+	for _, card := range deck {
+
+		if _, ok := copies[card.Name]; !ok {
+			names = append(names, card.Name)
+		}
+
+		copies[card.Name] = append(copies[card.Name], card.UID)
+
+	}
+
+	sort.Strings(names)
+
+	violations := make([]Violation, 0)
+
+	for _, name := range names {
+
+		if len(copies[name]) <= r.Max {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Rule:    "copy_limit",
+			Message: fmt.Sprintf("The deck has %v copies of %s, at most %v are allowed", len(copies[name]), name, r.Max),
+			Cards:   copies[name][:1],
+		})
+
+	}
+
+	return violations
+
+}
+
+// Sets only allows cards from the specified sets
+type Sets struct {
+	Allowed []string
+}
+
+// Check returns a violation for every card that is not from one of the allowed sets
+func (r Sets) Check(deck []Card) []Violation {
+
+	allowed := make(map[string]bool)
+
+	for _, set := range r.Allowed {
+		allowed[set] = true
+	}
+
+	seen := make(map[string]bool)
+	violations := make([]Violation, 0)
+
+	for _, card := range deck {
+
+		if allowed[card.Set] || seen[card.UID] {
+			continue
+		}
+
+		seen[card.UID] = true
+
+		violations = append(violations, Violation{
+			Rule:    "set",
+			Message: fmt.Sprintf("%s is from %s which is not allowed", card.Name, card.Set),
+			Cards:   []string{card.UID},
+		})
+
+	}
+
+	return violations
+
+}
+
+// Banned does not allow the cards with the specified uids
+type Banned struct {
+	Cards []string
+}
+
+// Check returns a violation for every banned card in the deck
+func (r Banned) Check(deck []Card) []Violation {
+
+	banned := make(map[string]bool)
+
+	for _, uid := range r.Cards {
+		banned[uid] = true
+	}
+
+	seen := make(map[string]bool)
+	violations := make([]Violation, 0)
+
+	for _, card := range deck {
+
+		if !banned[card.UID] || seen[card.UID] {
+			continue
+		}
+
+		seen[card.UID] = true
+
+		violations = append(violations, Violation{
+			Rule:    "banned",
+			Message: fmt.Sprintf("%s is banned", card.Name),
+			Cards:   []string{card.UID},
+		})
+
+	}
+
+	return violations
+
+}
@@ -58,32 +58,11 @@ func Creature(card *match.Card, ctx *match.Context) {
 				return
 			}
 
-			manaCost := card.ManaCost
-			for _, condition := range card.Conditions() {
-				if condition.ID == cnd.ReducedCost {
-					manaCost -= condition.Val.(int)
-					if manaCost < 1 {
-						manaCost = 1
-					}
-				}
-
-				if condition.ID == cnd.IncreasedCost {
-					manaCost += condition.Val.(int)
-				}
-			}
-
-			ctx.Match.NewAction(
-				card.Player,
-				untappedMana,
-				manaCost,
-				manaCost,
-				fmt.Sprintf("Select %v cards from your manazone to play %v. You must select at least 1 %v, civilization card.", manaCost, card.Name, card.Civ),
-				true,
-			)
+			ctx.Match.NewPaymentAction(card.Player, card, untappedMana, true)
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if action.Cancel {
 					ctx.Match.CloseAction(card.Player)
@@ -103,11 +82,16 @@ func Creature(card *match.Card, ctx *match.Context) {
 					cards = append(cards, mana)
 				}
 
-				if len(action.Cards) != manaCost || !match.AssertCardsIn(untappedMana, action.Cards...) || !card.Player.CanPlayCard(card, cards) {
+				if len(cards) != len(action.Cards) || !match.AssertCardsIn(untappedMana, action.Cards...) {
 					ctx.Match.ActionWarning(card.Player, "Your selection of cards does not fulfill the requirements")
 					continue
 				}
 
+				if err := card.Player.ValidatePayment(card, cards); err != nil {
+					ctx.Match.ActionWarning(card.Player, err.Error())
+					continue
+				}
+
 				ctx.Match.CloseAction(card.Player)
 
 				cardPlayedCtx := match.NewContext(ctx.Match, &match.CardPlayedEvent{
@@ -192,7 +176,7 @@ func Creature(card *match.Card, ctx *match.Context) {
 
 				for {
 
-					action := <-card.Player.Action
+					action := card.Player.WaitForAction()
 
 					if action.Cancel {
 						ctx.InterruptFlow()
@@ -242,15 +226,17 @@ func Creature(card *match.Card, ctx *match.Context) {
 
 				for {
 
-					action := <-opponent.Action
+					action := opponent.WaitForAction()
 
 					if action.Cancel {
 						ctx.Match.EndWait(card.Player)
 						ctx.Match.CloseAction(opponent)
 
+						ctx.Match.HandleFx(match.NewContext(ctx.Match, &match.AttackConfirmed{CardID: card.ID, Player: true, Creature: false}))
+
 						if len(shieldzone) < 1 {
 							// Win
-							ctx.Match.End(card.Player, fmt.Sprintf("%s won the game", ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+							ctx.Match.End(card.Player, match.ShieldsBroken, fmt.Sprintf("%s won the game", ctx.Match.PlayerRef(card.Player).Username))
 						} else {
 							// Break n shields
 							ctx.Match.BreakShields(shieldsAttacked)
@@ -290,7 +276,7 @@ func Creature(card *match.Card, ctx *match.Context) {
 
 				if len(shieldzone) < 1 {
 					// Win
-					ctx.Match.End(card.Player, fmt.Sprintf("%s won the game", ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+					ctx.Match.End(card.Player, match.ShieldsBroken, fmt.Sprintf("%s won the game", ctx.Match.PlayerRef(card.Player).Username))
 				} else {
 					// Break n shields
 					ctx.Match.BreakShields(shieldsAttacked)
@@ -354,7 +340,7 @@ func Creature(card *match.Card, ctx *match.Context) {
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if action.Cancel {
 					ctx.InterruptFlow()
@@ -398,7 +384,7 @@ func Creature(card *match.Card, ctx *match.Context) {
 
 				for {
 
-					action := <-opponent.Action
+					action := opponent.WaitForAction()
 
 					if action.Cancel {
 						ctx.Match.EndWait(card.Player)
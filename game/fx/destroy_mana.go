@@ -26,7 +26,7 @@ func DestroyManaOnSummon(card *match.Card, ctx *match.Context) {
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if len(action.Cards) != 1 || !match.AssertCardsIn(manazone, action.Cards[0]) {
 					ctx.Match.ActionWarning(card.Player, "Your selection of cards does not fulfill the requirements")
@@ -36,7 +36,7 @@ func DestroyManaOnSummon(card *match.Card, ctx *match.Context) {
 				c, err := card.Player.MoveCard(action.Cards[0], match.MANAZONE, match.GRAVEYARD)
 
 				if err != nil {
-					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved from %s's manazone to the graveyard", c.Name, ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+					ctx.Match.Chat("Server", fmt.Sprintf("%s was moved from %s's manazone to the graveyard", c.Name, ctx.Match.PlayerRef(card.Player).Username))
 				}
 
 				break
@@ -63,7 +63,7 @@ func DrawToMana(card *match.Card, ctx *match.Context) {
 				return
 			}
 
-			ctx.Match.Chat("Server", fmt.Sprintf("%s was added to %s's manazone from the top of their deck", c.Name, ctx.Match.PlayerRef(card.Player).Socket.User.Username))
+			ctx.Match.Chat("Server", fmt.Sprintf("%s was added to %s's manazone from the top of their deck", c.Name, ctx.Match.PlayerRef(card.Player).Username))
 
 		}
 
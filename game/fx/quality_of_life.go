@@ -51,6 +51,18 @@ func When(test func(*match.Card, *match.Context) bool, h func(*match.Card, *matc
 
 }
 
+// Trigger queues the specified function as a triggered ability of the card if the test
+// is successful, it is resolved after the event together with the other triggered abilities
+func Trigger(test func(*match.Card, *match.Context) bool, h func(*match.Card, *match.Context)) func(*match.Card, *match.Context) {
+
+	return func(card *match.Card, ctx *match.Context) {
+		if test(card, ctx) {
+			ctx.Trigger(card, func() { h(card, ctx) })
+		}
+	}
+
+}
+
 // Select prompts the user to select n cards from the specified container
 func Select(p *match.Player, m *match.Match, containerOwner *match.Player, containerName string, text string, min int, max int, cancellable bool) CardCollection {
 	return SelectFilter(p, m, containerOwner, containerName, text, min, max, cancellable, func(x *match.Card) bool { return true })
@@ -85,7 +97,7 @@ func SelectFilter(p *match.Player, m *match.Match, containerOwner *match.Player,
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
@@ -139,7 +151,7 @@ func SelectMultipart(p *match.Player, m *match.Match, cards map[string][]*match.
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
@@ -204,7 +216,7 @@ func SelectBacksideFilter(p *match.Player, m *match.Match, containerOwner *match
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
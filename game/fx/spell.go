@@ -50,32 +50,11 @@ func Spell(card *match.Card, ctx *match.Context) {
 				return
 			}
 
-			manaCost := card.ManaCost
-			for _, condition := range card.Conditions() {
-				if condition.ID == cnd.ReducedCost {
-					manaCost -= condition.Val.(int)
-					if manaCost < 1 {
-						manaCost = 1
-					}
-				}
-
-				if condition.ID == cnd.IncreasedCost {
-					manaCost += condition.Val.(int)
-				}
-			}
-
-			ctx.Match.NewAction(
-				card.Player,
-				untappedMana,
-				manaCost,
-				manaCost,
-				fmt.Sprintf("Select %v cards from your manazone to play %v. You must select at least 1 %v, civilization card.", manaCost, card.Name, card.Civ),
-				true,
-			)
+			ctx.Match.NewPaymentAction(card.Player, card, untappedMana, true)
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if action.Cancel {
 					ctx.Match.CloseAction(card.Player)
@@ -95,11 +74,16 @@ func Spell(card *match.Card, ctx *match.Context) {
 					cards = append(cards, mana)
 				}
 
-				if len(action.Cards) != manaCost || !match.AssertCardsIn(untappedMana, action.Cards...) || !card.Player.CanPlayCard(card, cards) {
+				if len(cards) != len(action.Cards) || !match.AssertCardsIn(untappedMana, action.Cards...) {
 					ctx.Match.ActionWarning(card.Player, "Your selection of cards does not fulfill the requirements")
 					continue
 				}
 
+				if err := card.Player.ValidatePayment(card, cards); err != nil {
+					ctx.Match.ActionWarning(card.Player, err.Error())
+					continue
+				}
+
 				ctx.Match.CloseAction(card.Player)
 
 				for _, mana := range cards {
@@ -2,8 +2,8 @@ package game
 
 import (
 	"duel-masters/game/match"
+	"duel-masters/metrics"
 	"duel-masters/server"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sync"
@@ -28,6 +28,17 @@ var messagesMutex = &sync.Mutex{}
 var subscribers = make([]*server.Socket, 0)
 var subscribersMutex = &sync.Mutex{}
 
+func init() {
+
+	metrics.NewGaugeFunc("duelmasters_lobby_subscribers", "Sockets subscribed to the lobby", "", func() map[string]float64 {
+		subscribersMutex.Lock()
+		defer subscribersMutex.Unlock()
+
+		return map[string]float64{"": float64(len(subscribers))}
+	})
+
+}
+
 var userCache server.UserListMessage = server.GetUserList()
 var matchCache server.MatchesListMessage = server.MatchesListMessage{
 	Header:  "matches",
@@ -41,7 +52,7 @@ type Lobby struct{}
 
 // Name just returns "lobby", obligatory for a hub
 func (l *Lobby) Name() string {
-	return "lobby"
+	return server.LobbyHub
 }
 
 // GetLobby returns a reference to the lobby
@@ -69,10 +80,12 @@ func (l *Lobby) StartTicker() {
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Errorf("Recovered from lobby ticker. %v", r)
+			metrics.Panics.Inc("lobby_ticker")
 		}
 	}()
 
 	go ListenForMatchListUpdates()
+	go Matchmaker()
 
 	for {
 
@@ -128,20 +141,16 @@ func PinMessage(message string) {
 }
 
 // Parse websocket messages
-func (l *Lobby) Parse(s *server.Socket, data []byte) {
+func (l *Lobby) Parse(s *server.Socket, r *server.Request) {
 
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Warnf("Recovered from parsing a message in lobby. %v", r)
+			metrics.Panics.Inc("lobby_parse")
 		}
 	}()
 
-	var message server.Message
-	if err := json.Unmarshal(data, &message); err != nil {
-		return
-	}
-
-	switch message.Header {
+	switch r.Header {
 
 	case "subscribe":
 		{
@@ -179,17 +188,7 @@ func (l *Lobby) Parse(s *server.Socket, data []byte) {
 	case "chat":
 		{
 
-			var msg struct {
-				Message string `json:"message"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
-
-			if len(msg.Message) < 1 {
-				return
-			}
+			msg := r.Message.(*server.ChatRequest)
 
 			runes := []rune(msg.Message)
 			if string(runes[0:1]) == "/" {
@@ -222,6 +221,21 @@ func (l *Lobby) Parse(s *server.Socket, data []byte) {
 
 		}
 
+	case "queue":
+		{
+			joinQueue(s)
+		}
+
+	case "leave_queue":
+		{
+			leaveQueue(s)
+
+			s.Send(server.QueueMessage{
+				Header: "queue",
+				Queued: false,
+			})
+		}
+
 	}
 
 }
@@ -301,6 +315,8 @@ func handleChatCommand(s *server.Socket, command string) {
 // OnSocketClose is called when a socket disconnects
 func (l *Lobby) OnSocketClose(s *server.Socket) {
 
+	leaveQueue(s)
+
 	subscribersMutex.Lock()
 	defer subscribersMutex.Unlock()
 
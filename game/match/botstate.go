@@ -0,0 +1,125 @@
+package match
+
+import "duel-masters/server"
+
+// botStateVersion is the protocol version that added bot_state
+const botStateVersion = 2
+
+// isBot returns true if the player is connected through a bot account that receives bot_state messages
+func isBot(p *PlayerReference) bool {
+
+	s, ok := p.Controller.(*server.Socket)
+
+	return ok && s != nil && s.User.Bot && s.Version >= botStateVersion
+
+}
+
+// sendState sends the state update to the player, bots get it together with their legal
+// actions and pending prompt instead
+func (m *Match) sendState(p *PlayerReference, state *server.MatchStateMessage) {
+
+	if p.Controller == nil {
+		return
+	}
+
+	if !isBot(p) {
+		p.Controller.Send(state)
+		return
+	}
+
+	p.Controller.Send(&server.BotStateMessage{
+		Header: "bot_state",
+		State:  state.State,
+		Legal:  m.legalActions(p.Player),
+		Prompt: m.pendingPromptState(p.Player),
+	})
+
+}
+
+// updateBot sends the state to the player if it is a bot, so the constraints
+// of a prompt are known as soon as it is opened
+func (m *Match) updateBot(p *Player) {
+
+	ref := m.PlayerRef(p)
+
+	if !isBot(ref) {
+		return
+	}
+
+	me := *p.Denormalized()
+	me.Username = ref.Username
+	me.Color = ref.Color
+
+	opponentRef := m.PlayerRef(m.Opponent(p))
+
+	opponent := *opponentRef.Player.denormalize(false)
+	opponent.Username = opponentRef.Username
+	opponent.Color = opponentRef.Color
+
+	m.sendState(ref, &server.MatchStateMessage{
+		Header: "state_update",
+		State: server.MatchState{
+			MyTurn:       m.IsPlayerTurn(p),
+			HasAddedMana: p.HasChargedMana,
+			Me:           me,
+			Opponent:     opponent,
+			Clock:        m.clockFor(p.Turn),
+		},
+	})
+
+}
+
+// legalActions returns the moves the player can make in the format of the protocol
+func (m *Match) legalActions(p *Player) server.LegalActionsState {
+
+	legal := m.LegalActions(p)
+
+	state := server.LegalActionsState{
+		Charge:    cardIDs(legal.Charge),
+		Play:      cardIDs(legal.Play),
+		Attackers: make([]server.AttackerState, 0),
+		EndTurn:   legal.EndTurn,
+	}
+
+	for _, attack := range legal.Attacks {
+		state.Attackers = append(state.Attackers, server.AttackerState{
+			CardID:    attack.Card.ID,
+			Player:    attack.Player,
+			Creatures: cardIDs(attack.Creatures),
+		})
+	}
+
+	return state
+
+}
+
+// pendingPromptState returns the constraints of the player's pending prompt, or nil
+func (m *Match) pendingPromptState(p *Player) *server.PromptState {
+
+	pending := m.pendingPrompt(p)
+
+	if pending == nil {
+		return nil
+	}
+
+	state := &server.PromptState{
+		Cards:         pending.cards,
+		MinSelections: pending.min,
+		MaxSelections: pending.max,
+		Cancellable:   pending.cancellable,
+	}
+
+	switch msg := pending.msg.(type) {
+
+	case *server.ActionMessage:
+		state.Text = msg.Text
+		state.Suggested = msg.Suggested
+
+	case *server.MultipartActionMessage:
+		state.Text = msg.Text
+
+	}
+
+	return state
+
+}
@@ -2,7 +2,6 @@ package match
 
 import (
 	"github.com/sirupsen/logrus"
-	"github.com/ventu-io/go-shortid"
 )
 
 // Condition is used to store turn-specific state to the card such as power amplifiers
@@ -28,6 +27,7 @@ type Card struct {
 	ManaRequirement []string
 	PowerModifier   func(m *Match, attacking bool) int
 
+	civs          []string
 	attachedCards []*Card
 	conditions    []Condition
 	handlers      []HandlerFunc
@@ -36,7 +36,7 @@ type Card struct {
 // NewCard returns a new, initialized card
 func NewCard(p *Player, image string) (*Card, error) {
 
-	id, err := shortid.Generate()
+	id, err := newID()
 
 	if err != nil {
 		logrus.Debug("Failed to generate id for card")
@@ -77,6 +77,44 @@ func (c *Card) Use(handlers ...HandlerFunc) {
 	c.handlers = append(c.handlers, handlers...)
 }
 
+// AddCivilizations makes the card a multi civilization card, the card is of the
+// specified civilizations in addition to Civ
+func (c *Card) AddCivilizations(civs ...string) {
+	c.civs = append(c.civs, civs...)
+}
+
+// Civilizations returns every civilization of the card, starting with Civ
+func (c *Card) Civilizations() []string {
+
+	result := []string{c.Civ}
+
+	for _, civ := range c.civs {
+		if civ != c.Civ {
+			result = append(result, civ)
+		}
+	}
+
+	return result
+
+}
+
+// HasCiv returns true if the card is of the given civilization
+func (c *Card) HasCiv(civ string) bool {
+
+	if c.Civ == civ {
+		return true
+	}
+
+	for _, x := range c.civs {
+		if x == civ {
+			return true
+		}
+	}
+
+	return false
+
+}
+
 // Conditions returns a slice with the cards conditions
 func (c *Card) Conditions() []Condition {
 	return c.conditions
@@ -0,0 +1,271 @@
+package match
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"duel-masters/metrics"
+	"duel-masters/server"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Clock limits how long the players of a match can take. TurnTime is the length of
+// each turn, after which the turn is ended. TotalTime is the time each player has
+// for the whole match like a chess clock, a player loses when it runs out.
+// The time of a player runs while the match is waiting for them to act, i.e. during
+// their own turn and while they have to respond to a prompt in their opponent's turn.
+// A zero duration means there is no limit
+type Clock struct {
+	TurnTime  time.Duration
+	TotalTime time.Duration
+}
+
+// limited returns true if the clock has any limit
+func (c Clock) limited() bool {
+	return c.TurnTime > 0 || c.TotalTime > 0
+}
+
+// clockState keeps track of the time the players have used
+type clockState struct {
+	turnStart time.Time
+	remaining map[byte]time.Duration
+	waitingOn byte
+	since     time.Time
+	forced    int
+	timedOut  bool
+	mutex     sync.Mutex
+}
+
+// charge subtracts the time since the last charge from the player the match is waiting on
+func (s *clockState) charge(now time.Time) {
+
+	if s.waitingOn != 0 {
+		s.remaining[s.waitingOn] -= now.Sub(s.since)
+	}
+
+	s.since = now
+
+}
+
+// SetClock sets the time limits of the match, it has to be called before the match is started
+func (m *Match) SetClock(c Clock) {
+	m.clock = c
+}
+
+// GetClock returns the time limits of the match
+func (m *Match) GetClock() Clock {
+	return m.clock
+}
+
+// clockNewTurn starts the turn time of the current player
+func (m *Match) clockNewTurn() {
+
+	if !m.clock.limited() {
+		return
+	}
+
+	now := m.now()
+
+	s := &m.clockState
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.remaining == nil {
+		s.remaining = map[byte]time.Duration{1: m.clock.TotalTime, 2: m.clock.TotalTime}
+		s.since = now
+	}
+
+	s.charge(now)
+	s.turnStart = now
+	s.waitingOn = m.Turn
+
+}
+
+// clockWaitFor charges the time to the player the match is now waiting on,
+// the current player if p is nil
+func (m *Match) clockWaitFor(p *Player) {
+
+	if !m.clock.limited() {
+		return
+	}
+
+	s := &m.clockState
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.remaining == nil {
+		return
+	}
+
+	s.charge(m.now())
+
+	if p != nil {
+		s.waitingOn = p.Turn
+	} else {
+		s.waitingOn = m.Turn
+	}
+
+}
+
+// clockFor returns the remaining time of the match for the player with the specified turn
+func (m *Match) clockFor(turn byte) *server.ClockState {
+
+	if !m.clock.limited() {
+		return nil
+	}
+
+	s := &m.clockState
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.remaining == nil {
+		return nil
+	}
+
+	now := m.now()
+
+	state := &server.ClockState{Turn: -1, Me: -1, Opponent: -1}
+
+	if m.clock.TurnTime > 0 {
+		state.Turn = seconds(m.clock.TurnTime - now.Sub(s.turnStart))
+	}
+
+	if m.clock.TotalTime > 0 {
+
+		remaining := func(t byte) int {
+			d := s.remaining[t]
+			if s.waitingOn == t {
+				d -= now.Sub(s.since)
+			}
+			return seconds(d)
+		}
+
+		opponent := byte(1)
+		if turn == 1 {
+			opponent = 2
+		}
+
+		state.Me = remaining(turn)
+		state.Opponent = remaining(opponent)
+
+	}
+
+	return state
+
+}
+
+func seconds(d time.Duration) int {
+
+	if d < 0 {
+		return 0
+	}
+
+	return int(d / time.Second)
+
+}
+
+// checkClock ends the turn or the match if a player ran out of time
+func (m *Match) checkClock() {
+
+	if !m.Started || m.ending || !m.clock.limited() {
+		return
+	}
+
+	now := m.now()
+
+	s := &m.clockState
+	s.mutex.Lock()
+
+	if s.remaining == nil || s.timedOut {
+		s.mutex.Unlock()
+		return
+	}
+
+	s.charge(now)
+
+	var outOfTime byte
+
+	if m.clock.TotalTime > 0 && s.remaining[s.waitingOn] <= 0 {
+		outOfTime = s.waitingOn
+		s.timedOut = true
+	}
+
+	turns := m.turns
+	turnOver := m.clock.TurnTime > 0 && now.Sub(s.turnStart) >= m.clock.TurnTime && s.forced != turns
+
+	if turnOver {
+		s.forced = turns
+	}
+
+	s.mutex.Unlock()
+
+	if outOfTime != 0 {
+		m.timeout(outOfTime)
+		return
+	}
+
+	if turnOver {
+		m.forceEndTurn(turns)
+	}
+
+}
+
+// timeout ends the match after the player with the specified turn ran out of time
+func (m *Match) timeout(turn byte) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from match timeout. %v", r)
+			metrics.Panics.Inc("match_timeout")
+		}
+	}()
+
+	loser := m.Player1
+	winner := m.Player2
+
+	if turn == 2 {
+		loser, winner = winner, loser
+	}
+
+	// ending the match answers the pending prompts on behalf of the players
+	m.End(winner.Player, Timeout, fmt.Sprintf("%s ran out of time, %s won the game", loser.Username, winner.Username))
+
+}
+
+// forceEndTurn ends the turn after the turn time ran out, without firing the EndTurnEvent
+func (m *Match) forceEndTurn(turns int) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from forcing the end of a turn. %v", r)
+			metrics.Panics.Inc("match_turn_timeout")
+		}
+	}()
+
+	m.Chat("Server", fmt.Sprintf("%s's time for the turn ran out", m.CurrentPlayer().Username))
+
+	// The pending prompts are answered on behalf of the players,
+	// the turn is ended once the move that sent them is done
+	m.cancelling = true
+
+	m.queue(func() {
+
+		// the game ended while the prompts were answered, the cancellation is
+		// then kept until the match is closed or the next game starts
+		if m.ending {
+			return
+		}
+
+		m.cancelling = false
+
+		if m.turns != turns {
+			return
+		}
+
+		m.EndStep()
+
+	})
+
+}
@@ -0,0 +1,165 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTime is a time source that only moves forward when told to
+type fakeTime struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func (f *fakeTime) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeTime) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// newClockMatch returns a headless match with the clock measured by a fake time source
+func newClockMatch(c match.Clock) (*match.Match, *fakeTime) {
+
+	m := match.NewHeadless("clock")
+	f := &fakeTime{now: time.Now()}
+
+	match.SetTimeSource(m, f.Now)
+	m.SetClock(c)
+
+	return m, f
+
+}
+
+func TestTurnTime(t *testing.T) {
+
+	m, f := newClockMatch(match.Clock{TurnTime: 30 * time.Second})
+	defer m.Dispose()
+
+	startMatch(m)
+
+	var first string
+
+	m.Do(func() { first = m.CurrentPlayer().UID })
+
+	f.Advance(29 * time.Second)
+	match.CheckClock(m)
+
+	m.Do(func() {
+		if m.CurrentPlayer().UID != first {
+			t.Error("Expected the turn not to end before the turn time ran out")
+		}
+	})
+
+	f.Advance(2 * time.Second)
+	match.CheckClock(m)
+
+	m.Do(func() {
+		if m.CurrentPlayer().UID == first {
+			t.Error("Expected the turn to be ended once the turn time ran out")
+		}
+	})
+
+}
+
+func TestTotalTime(t *testing.T) {
+
+	m, f := newClockMatch(match.Clock{TotalTime: time.Minute})
+	defer m.Dispose()
+
+	r1, _ := startMatch(m)
+
+	f.Advance(61 * time.Second)
+	match.CheckClock(m)
+
+	// the match is closed once it ended
+	result := gameOver(r1)
+
+	if result == nil {
+		t.Fatal("Expected the match to end once a player ran out of time")
+	}
+
+	if result.Reason != match.Timeout {
+		t.Errorf("Expected the match to end with reason %s, got %+v", match.Timeout, result)
+	}
+
+}
+
+// TestTurnTimeEndsGame runs out of turn time while a prompt is pending, answering the
+// prompt ends the game and the match stays open for the next game of the series
+func TestTurnTimeEndsGame(t *testing.T) {
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	m, f := newClockMatch(match.Clock{TurnTime: 30 * time.Second})
+	defer m.Dispose()
+
+	m.SetSeries(match.NewSeries(3))
+
+	r1, _ := startMatch(m)
+
+	var first *match.PlayerReference
+
+	m.Do(func() {
+		first = m.CurrentPlayer()
+
+		if first != m.Player1 {
+			m.EndTurn()
+			first = m.CurrentPlayer()
+		}
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+
+		defer close(done)
+
+		m.Do(func() {
+
+			p := first.Player
+
+			m.NewAction(p, nil, 0, 1, "Select up to one card", false)
+			p.WaitForAction()
+			m.CloseAction(p)
+
+			m.End(m.Player2.Player, match.Forfeit, "p1 lost")
+
+		})
+
+	}()
+
+	// the prompt is open once player1 was sent it
+	for prompted := false; !prompted; time.Sleep(time.Millisecond) {
+		for _, msg := range r1.Messages() {
+			if _, ok := msg.(*server.ActionMessage); ok {
+				prompted = true
+			}
+		}
+	}
+
+	f.Advance(31 * time.Second)
+	match.CheckClock(m)
+
+	<-done
+
+	if gameOver(r1) == nil {
+		t.Fatal("Expected the game to end after the prompt was answered")
+	}
+
+	if !match.Cancelling(m) {
+		t.Error("Expected the prompts to still be answered on behalf of the players after the game ended")
+	}
+
+}
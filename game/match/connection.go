@@ -0,0 +1,156 @@
+package match
+
+import (
+	"fmt"
+	"time"
+
+	"duel-masters/metrics"
+	"duel-masters/server"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DisconnectGrace is how long a disconnected player has to reconnect before they forfeit the match
+var DisconnectGrace = 60 * time.Second
+
+// PongTimeout is how long a connection can go without answering a ping before it is closed
+var PongTimeout = 30 * time.Second
+
+// ping sends a ping to the players and spectators and closes the connections that stopped answering
+func (m *Match) ping() {
+
+	now := time.Now().Unix()
+	timeout := int64(PongTimeout / time.Second)
+
+	stale := make([]*server.Socket, 0)
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if p == nil {
+			continue
+		}
+
+		s, ok := p.Controller.(*server.Socket)
+
+		if !ok || s == nil {
+			continue
+		}
+
+		if now-p.LastPong > timeout {
+			stale = append(stale, s)
+			continue
+		}
+
+		s.Send(server.Message{Header: "mping"})
+
+	}
+
+	m.spectators.RLock()
+
+	for _, spectator := range m.spectators.users {
+
+		if spectator.Socket == nil {
+			continue
+		}
+
+		if now-spectator.LastPong > timeout {
+			stale = append(stale, spectator.Socket)
+			continue
+		}
+
+		spectator.Socket.Send(server.Message{Header: "mping"})
+
+	}
+
+	m.spectators.RUnlock()
+
+	// closing the socket calls OnSocketClose which handles the disconnect
+	for _, s := range stale {
+		logrus.Debugf("Closing connection of %s in match %s that stopped answering pings", s.User.Username, m.ID)
+		s.Close()
+	}
+
+}
+
+// Reconnect replaces the controller of a player who lost their connection. The player
+// is sent the state of the match and the prompt they have not yet responded to, the
+// card that sent the prompt is still waiting for the response
+func (m *Match) Reconnect(p *PlayerReference, c PlayerController) {
+
+	if p.Controller != nil {
+		p.Controller.Close()
+	}
+
+	p.Controller = c
+	p.LastPong = time.Now().Unix()
+	p.disconnected = time.Time{}
+
+	o := m.Player1
+
+	if o == p {
+		o = m.Player2
+	}
+
+	if o != nil && o.Controller != nil {
+		o.Controller.Send(server.Message{
+			Header: "opponent_reconnected",
+		})
+	}
+
+	m.BroadcastState()
+	m.resendPrompt(p)
+	m.Chat("Server", p.Username+" reconnected")
+
+}
+
+// checkDisconnected counts down the time disconnected players have left to reconnect
+// and lets them forfeit the match when it runs out
+func (m *Match) checkDisconnected() {
+
+	if !m.Started || m.ending || m.forfeiting {
+		return
+	}
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if p == nil || p.Controller != nil || p.disconnected.IsZero() {
+			continue
+		}
+
+		left := DisconnectGrace - time.Since(p.disconnected)
+
+		if left <= 0 {
+			m.forfeiting = true
+			m.forfeit(p)
+			return
+		}
+
+		m.Broadcast(server.ReconnectCountdownMessage{
+			Header:   "reconnect_countdown",
+			Username: p.Username,
+			Seconds:  int((left + time.Second - 1) / time.Second),
+		})
+
+	}
+
+}
+
+// forfeit ends the match after the player did not reconnect in time
+func (m *Match) forfeit(p *PlayerReference) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from forfeiting a match. %v", r)
+			metrics.Panics.Inc("match_forfeit")
+		}
+	}()
+
+	o := m.Player1
+
+	if o == p {
+		o = m.Player2
+	}
+
+	m.End(o.Player, Forfeit, fmt.Sprintf("%s did not reconnect in time, %s won the game", p.Username, o.Username))
+
+}
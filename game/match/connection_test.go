@@ -0,0 +1,155 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"duel-masters/server"
+	"sync"
+	"testing"
+)
+
+// recorder is a controller that records the messages it was sent without responding
+type recorder struct {
+	messages []interface{}
+	mutex    sync.Mutex
+}
+
+func (r *recorder) Send(msg interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages = append(r.messages, msg)
+}
+
+func (r *recorder) Close() {}
+
+func (r *recorder) Messages() []interface{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]interface{}{}, r.messages...)
+}
+
+func TestReconnectResendsPrompt(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{brawlerZyler}},
+		matchtest.Board{Shieldzone: []string{brawlerZyler}, Battlezone: []string{brawlerZyler}},
+	)
+
+	before := &recorder{}
+	after := &recorder{}
+	again := &recorder{}
+	waiting := &recorder{}
+	pending := false
+
+	// the prompt is closed once the command is done, so it is all done in one command
+	h.Do(func() {
+		h.Match.Reconnect(h.P2.PlayerReference, before)
+
+		creatures, _ := h.P2.Player.Container(match.BATTLEZONE)
+		h.Match.NewAction(h.P2.Player, creatures, 1, 1, "Select a creature", false)
+		h.Match.Wait(h.P1.Player, "Waiting for your opponent to make an action")
+
+		h.Match.Reconnect(h.P2.PlayerReference, after)
+
+		pending = h.Match.HasPendingPrompt(h.P2.Player)
+
+		h.Match.CloseAction(h.P2.Player)
+		h.Match.Reconnect(h.P2.PlayerReference, again)
+		h.Match.Reconnect(h.P1.PlayerReference, waiting)
+	})
+
+	var prompt *server.ActionMessage
+
+	for _, msg := range after.Messages() {
+		if action, ok := msg.(*server.ActionMessage); ok {
+			prompt = action
+		}
+	}
+
+	if prompt == nil || prompt.Text != "Select a creature" || prompt.MinSelections != 1 || prompt.Cancellable {
+		t.Fatalf("Expected the pending prompt to be sent again after reconnecting, got %v", after.Messages())
+	}
+
+	if !pending {
+		t.Error("Expected the prompt to still be pending after reconnecting")
+	}
+
+	for _, msg := range again.Messages() {
+		if _, ok := msg.(*server.ActionMessage); ok {
+			t.Error("Expected a closed prompt not to be sent again after reconnecting")
+		}
+	}
+
+	found := false
+
+	for _, msg := range waiting.Messages() {
+		if wait, ok := msg.(server.WaitMessage); ok && wait.Message == "Waiting for your opponent to make an action" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected the waiting popup to be sent again after reconnecting, got %v", waiting.Messages())
+	}
+
+}
+
+// disconnecter answers the prompts of its player and disconnects right after answering
+// the prompt with the given text, before the card that sent it has resolved
+type disconnecter struct {
+	recorder
+	ref  *match.PlayerReference
+	text string
+}
+
+func (d *disconnecter) Send(msg interface{}) {
+
+	d.recorder.Send(msg)
+
+	prompt, ok := msg.(*server.ActionMessage)
+
+	if !ok {
+		return
+	}
+
+	cards := make([]string, 0)
+
+	for i := 0; i < prompt.MinSelections; i++ {
+		cards = append(cards, prompt.Cards[i].CardID)
+	}
+
+	if len(prompt.Suggested) > 0 {
+		cards = prompt.Suggested
+	}
+
+	if prompt.Text == d.text {
+		d.ref.Controller = nil
+	}
+
+	go d.ref.Player.Respond(match.PlayerAction{Cards: cards})
+
+}
+
+func TestShowCardsWhileDisconnected(t *testing.T) {
+
+	const reconOperation = "aa1cca8a-3140-4180-9c9f-3f126dd16a68"
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{reconOperation}, Manazone: []string{reconOperation, reconOperation}},
+		matchtest.Board{Shieldzone: []string{brawlerZyler, brawlerZyler}},
+	)
+
+	d := &disconnecter{
+		ref:  h.P1.PlayerReference,
+		text: "Recon Operation: Select 3 of your opponent's shields that will be shown to you",
+	}
+
+	h.Do(func() { h.Match.Reconnect(h.P1.PlayerReference, d) })
+
+	h.P1.Play(reconOperation)
+
+	h.P1.AssertZone(match.GRAVEYARD, reconOperation)
+
+}
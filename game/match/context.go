@@ -3,10 +3,18 @@ package match
 // Context is events passed down to cards, allowing them to perform actions
 // without having a direct reference to the match, players etc
 type Context struct {
-	Match   *Match
-	Event   interface{}
-	cancel  bool
-	postFxs []func()
+	Match    *Match
+	Event    interface{}
+	cancel   bool
+	postFxs  []func()
+	post     int
+	triggers []*trigger
+}
+
+// trigger is a triggered ability waiting to be resolved
+type trigger struct {
+	card    *Card
+	resolve func()
 }
 
 // HandlerFunc is a function with a match context as argument
@@ -31,7 +39,20 @@ func (c *Context) ScheduleAfter(handlers ...func()) {
 	c.postFxs = append(c.postFxs, handlers...)
 }
 
-// InterruptFlow stops the context flow, cancelling the default behaviour
+// Trigger queues a triggered ability of the card. The queued abilities are resolved
+// one at a time once the event has finished, in the order chosen by their controller
+func (c *Context) Trigger(card *Card, h func()) {
+
+	if len(c.triggers) < 1 {
+		c.ScheduleAfter(c.resolveTriggers)
+	}
+
+	c.triggers = append(c.triggers, &trigger{card: card, resolve: h})
+
+}
+
+// InterruptFlow stops the context flow, cancelling the default behaviour. Abilities
+// that already triggered on the event are resolved regardless
 func (c *Context) InterruptFlow() {
 	c.cancel = true
 }
@@ -40,3 +61,99 @@ func (c *Context) InterruptFlow() {
 func (c *Context) Cancelled() bool {
 	return c.cancel
 }
+
+// resolveTriggers resolves the queued triggered abilities after everything else
+// scheduled for the event. The abilities of the player in whose turn it is are
+// resolved first. Abilities that triggered before the event was cancelled are
+// still resolved
+func (c *Context) resolveTriggers() {
+
+	// Other effects were scheduled after the first ability triggered, wait for them
+	if !c.cancel && c.post < len(c.postFxs)-1 {
+		c.ScheduleAfter(c.resolveTriggers)
+		return
+	}
+
+	for len(c.triggers) > 0 {
+
+		if c.Match.Ended() {
+			c.triggers = nil
+			return
+		}
+
+		player := c.triggers[0].card.Player
+
+		for _, t := range c.triggers {
+			if c.Match.IsPlayerTurn(t.card.Player) {
+				player = t.card.Player
+				break
+			}
+		}
+
+		t := c.chooseTrigger(player)
+
+		if t == nil {
+			c.triggers = nil
+			return
+		}
+
+		for i, queued := range c.triggers {
+			if queued == t {
+				c.triggers = append(c.triggers[:i], c.triggers[i+1:]...)
+				break
+			}
+		}
+
+		t.resolve()
+
+	}
+
+}
+
+// chooseTrigger prompts the player to choose which of their queued abilities to
+// resolve next. The player is only prompted if the abilities come from different cards
+func (c *Context) chooseTrigger(p *Player) *trigger {
+
+	cards := make([]*Card, 0)
+	first := make(map[string]*trigger)
+
+	for _, t := range c.triggers {
+
+		if t.card.Player != p {
+			continue
+		}
+
+		if _, ok := first[t.card.ID]; !ok {
+			first[t.card.ID] = t
+			cards = append(cards, t.card)
+		}
+
+	}
+
+	if len(cards) < 2 {
+		return first[cards[0].ID]
+	}
+
+	m := c.Match
+	opponent := m.Opponent(p)
+
+	m.Wait(opponent, "Waiting for your opponent to choose the order of their abilities")
+	defer m.EndWait(opponent)
+
+	m.NewAction(p, cards, 1, 1, "Multiple abilities triggered at the same time. Choose the ability to resolve next", false)
+	defer m.CloseAction(p)
+
+	for {
+
+		action := p.WaitForAction()
+
+		if len(action.Cards) != 1 || !AssertCardsIn(cards, action.Cards...) {
+			m.ActionWarning(p, "The cards you selected does not meet the requirements")
+			continue
+		}
+
+		return first[action.Cards[0]]
+
+	}
+
+}
@@ -0,0 +1,108 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"reflect"
+	"testing"
+)
+
+const (
+	aquaHulcus  = "57eeb3c3-2561-4841-a381-2e50d17533d1"
+	phantomFish = "4b021e6f-39cf-401e-89cf-f164f7c0a797"
+	horridWorm  = "733a4f35-7470-40e3-9cd7-479aa965bfbb"
+)
+
+type triggerEvent struct{}
+
+func TestSimultaneousTriggersResolveInChosenOrder(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{aquaHulcus, phantomFish}},
+		matchtest.Board{Battlezone: []string{horridWorm}},
+	)
+
+	resolved := make([]string, 0)
+
+	for _, card := range []*match.Card{
+		h.P2.Card(match.BATTLEZONE, horridWorm),
+		h.P1.Card(match.BATTLEZONE, aquaHulcus),
+		h.P1.Card(match.BATTLEZONE, phantomFish),
+	} {
+		card.Use(func(card *match.Card, ctx *match.Context) {
+
+			if _, ok := ctx.Event.(*triggerEvent); !ok {
+				return
+			}
+
+			ctx.Trigger(card, func() { resolved = append(resolved, card.Name) })
+
+			ctx.ScheduleAfter(func() { resolved = append(resolved, "after "+card.Name) })
+
+		})
+	}
+
+	// Player1 is in turn and resolves their abilities first, player2 only has one
+	// ability and is therefore not prompted
+	h.P1.Respond(matchtest.Select(phantomFish))
+
+	h.Do(func() { h.Match.HandleFx(match.NewContext(h.Match, &triggerEvent{})) })
+
+	expected := []string{
+		"after Aqua Hulcus",
+		"after Phantom Fish",
+		"after Horrid Worm",
+		"Phantom Fish",
+		"Aqua Hulcus",
+		"Horrid Worm",
+	}
+
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Errorf("Expected the abilities to resolve as %v, got %v", expected, resolved)
+	}
+
+}
+
+func TestCancelledContextResolvesTriggers(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{aquaHulcus, phantomFish}},
+		matchtest.Board{},
+	)
+
+	resolved := make([]string, 0)
+
+	for _, card := range []*match.Card{
+		h.P1.Card(match.BATTLEZONE, aquaHulcus),
+		h.P1.Card(match.BATTLEZONE, phantomFish),
+	} {
+		card.Use(func(card *match.Card, ctx *match.Context) {
+
+			if _, ok := ctx.Event.(*triggerEvent); !ok {
+				return
+			}
+
+			ctx.Trigger(card, func() { resolved = append(resolved, card.Name) })
+
+			ctx.ScheduleAfter(func() { resolved = append(resolved, "after "+card.Name) })
+
+			if card.ImageID == phantomFish {
+				ctx.InterruptFlow()
+			}
+
+		})
+	}
+
+	h.P1.Respond(matchtest.Select(aquaHulcus))
+
+	h.Do(func() { h.Match.HandleFx(match.NewContext(h.Match, &triggerEvent{})) })
+
+	// The scheduled effects are cancelled together with the event, the abilities
+	// that already triggered are not
+	expected := []string{"Aqua Hulcus", "Phantom Fish"}
+
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Errorf("Expected the abilities to resolve as %v after the event was cancelled, got %v", expected, resolved)
+	}
+
+}
@@ -0,0 +1,154 @@
+package match
+
+import (
+	"duel-masters/server"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// scriptedAttempts is how many times a scripted controller answers the same prompt
+// before the default action is used instead
+const scriptedAttempts = 3
+
+// PlayerController is used by the match to communicate with a player.
+// Players connected through a websocket are controlled by their *server.Socket,
+// in-process controllers allow a match to be played without any network connection
+type PlayerController interface {
+	Send(msg interface{})
+	Close()
+}
+
+// LocalController is a PlayerController that runs in the same process as the match
+// and plays its player's turns on its own
+type LocalController interface {
+	PlayerController
+	// Attach is called when the controller is added to a match
+	Attach(m *Match, p *PlayerReference)
+	// TakeTurn is called at the start of each of the player's turns, the turn is ended
+	// when it returns. It is called outside of the loop of the match, so the controller
+	// has to look at the match and make its moves through Match.Do
+	TakeTurn()
+}
+
+// ScriptedController is a LocalController that plays according to the given functions.
+// Every message sent to the controller is recorded and can be retrieved with Messages()
+type ScriptedController struct {
+	// Turn performs the player's commands for the turn, such as charging mana,
+	// playing cards and attacking
+	Turn func(m *Match, p *PlayerReference)
+	// Respond returns the selection for an action prompt, the prompt is either a
+	// *server.ActionMessage or a *server.MultipartActionMessage. The prompt is
+	// cancelled if Respond is nil. If the response is rejected too many times the
+	// default action of the prompt is used
+	Respond func(m *Match, p *PlayerReference, prompt interface{}) PlayerAction
+
+	match    *Match
+	player   *PlayerReference
+	prompt   interface{}
+	attempts int
+	messages []interface{}
+	mutex    sync.Mutex
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewScriptedController returns a new scripted controller
+func NewScriptedController(turn func(m *Match, p *PlayerReference), respond func(m *Match, p *PlayerReference, prompt interface{}) PlayerAction) *ScriptedController {
+
+	return &ScriptedController{
+		Turn:     turn,
+		Respond:  respond,
+		messages: make([]interface{}, 0),
+		done:     make(chan struct{}),
+	}
+
+}
+
+// Attach stores the match and player the controller is playing for
+func (c *ScriptedController) Attach(m *Match, p *PlayerReference) {
+	c.match = m
+	c.player = p
+}
+
+// TakeTurn calls the Turn function of the controller in the loop of the match
+func (c *ScriptedController) TakeTurn() {
+
+	if c.Turn == nil {
+		return
+	}
+
+	c.match.Do(func() { c.Turn(c.match, c.player) })
+
+}
+
+// Send records the message and responds to action prompts
+func (c *ScriptedController) Send(msg interface{}) {
+
+	c.mutex.Lock()
+	c.messages = append(c.messages, msg)
+	c.mutex.Unlock()
+
+	switch msg.(type) {
+
+	case *server.ActionMessage, *server.MultipartActionMessage:
+		c.prompt = msg
+		c.attempts = 0
+		c.respond(msg)
+
+	case server.ActionWarningMessage:
+		// The previous selection did not meet the requirements and the
+		// match is still waiting for a response to the same prompt
+		if c.prompt != nil {
+			c.attempts++
+			c.respond(c.prompt)
+		}
+
+	case server.Message:
+		if msg.(server.Message).Header == "close_action" {
+			c.prompt = nil
+		}
+
+	}
+
+}
+
+func (c *ScriptedController) respond(prompt interface{}) {
+
+	action := PlayerAction{Cancel: true}
+
+	if c.attempts >= scriptedAttempts {
+		logrus.Warnf("Scripted controller in match %s was unable to answer the prompt, using the default action", c.match.ID)
+		action = c.match.DefaultAction(c.player.Player)
+	} else if c.Respond != nil {
+		action = c.Respond(c.match, c.player, prompt)
+	}
+
+	// Send is called from the loop of the match, which handles the response
+	// once it is done sending the prompt
+	go c.player.Player.Respond(action)
+
+}
+
+// Messages returns a copy of all messages sent to the controller
+func (c *ScriptedController) Messages() []interface{} {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result := make([]interface{}, len(c.messages))
+	copy(result, c.messages)
+
+	return result
+
+}
+
+// Close is called when the match is disposed
+func (c *ScriptedController) Close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Done returns a channel that is closed when the match is disposed
+func (c *ScriptedController) Done() <-chan struct{} {
+	return c.done
+}
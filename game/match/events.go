@@ -94,3 +94,11 @@ type GetPowerEvent struct {
 	Attacking bool
 	Power     int
 }
+
+// GetManaCostEvent is fired whenever a card's mana cost is to be used. Handlers add
+// to Increase and Reduction rather than changing the cost, so they are applied in the right order
+type GetManaCostEvent struct {
+	Card      *Card
+	Increase  int
+	Reduction int
+}
@@ -0,0 +1,38 @@
+package match
+
+import "time"
+
+// SetTimeSource replaces the time the clock of the match is measured with,
+// it has to be called before the match is started. The clock is then only
+// checked when CheckClock is called
+func SetTimeSource(m *Match, now func() time.Time) {
+	m.now = now
+	m.clockTicker.Stop()
+}
+
+// CheckClock checks the clock of the match in its loop, like the clock ticker does every
+// second. Like the ticker it is also handled while a prompt is pending
+func CheckClock(m *Match) {
+
+	e := event{fn: m.checkClock, done: make(chan struct{})}
+
+	if !m.post(e) {
+		return
+	}
+
+	select {
+	case <-e.done:
+	case <-m.stopped:
+	}
+
+}
+
+// Cancelling returns true if the pending prompts of the match are answered on behalf of the players
+func Cancelling(m *Match) bool {
+
+	cancelling := false
+	m.Do(func() { cancelling = m.cancelling })
+
+	return cancelling
+
+}
@@ -0,0 +1,122 @@
+package match
+
+import (
+	"duel-masters/metrics"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewHeadless returns a new match that is played in-process by LocalControllers.
+// Headless matches are not listed in the lobby, can't be joined through a hub
+// and their results are not saved. The loop of the match is started by the first
+// call to Do, so the players can be added and their decks created before that
+func NewHeadless(matchName string) *Match {
+
+	m := newMatch(matchName, "", false)
+	m.headless = true
+
+	logrus.Debugf("Created headless match %s", m.ID)
+
+	return m
+
+}
+
+// AddPlayer adds a player with the given controller to the match.
+// The first player added is player1 and the second player2
+func (m *Match) AddPlayer(uid string, username string, c PlayerController) (*PlayerReference, error) {
+
+	if m.Player1 != nil && m.Player2 != nil {
+		return nil, errors.New("The match already has two players")
+	}
+
+	var turn byte = 1
+
+	if m.Player1 != nil {
+		turn = 2
+	}
+
+	return m.addPlayer(turn, uid, username, c), nil
+
+}
+
+// AddComputerOpponent adds a local controller as player2 of a match hosted by a user,
+// the host joins as player1 through the hub. The results of matches against the
+// computer are not saved
+func (m *Match) AddComputerOpponent(uid string, username string, c LocalController) (*PlayerReference, error) {
+
+	if m.Player2 != nil {
+		return nil, errors.New("The match already has an opponent")
+	}
+
+	m.computer = true
+
+	return m.addPlayer(2, uid, username, c), nil
+
+}
+
+func (m *Match) addPlayer(turn byte, uid string, username string, c PlayerController) *PlayerReference {
+
+	ref := &PlayerReference{
+		UID:        uid,
+		Username:   username,
+		Player:     NewPlayer(m, turn),
+		Controller: c,
+		LastPong:   time.Now().Unix(),
+	}
+
+	if turn == 1 {
+		m.Player1 = ref
+	} else {
+		m.Player2 = ref
+	}
+
+	if lc, ok := c.(LocalController); ok {
+		lc.Attach(m, ref)
+	}
+
+	return ref
+
+}
+
+// takeTurn lets a local controller play the current turn and ends it afterwards.
+// It runs outside of the loop, the controller makes its moves through Do
+func (m *Match) takeTurn(c LocalController, turn int) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from local controller turn. %v", r)
+			metrics.Panics.Inc("local_controller_turn")
+		}
+	}()
+
+	// the turn end can be interrupted by cards, e.g. creatures that must attack,
+	// so the controller gets a few attempts to play the turn correctly
+	for i := 0; i < 3; i++ {
+
+		c.TakeTurn()
+
+		ended := true
+
+		m.Do(func() {
+
+			if m.Ended() || m.turns != turn {
+				return
+			}
+
+			m.EndTurn()
+
+			ended = m.turns != turn
+
+		})
+
+		if ended {
+			return
+		}
+
+	}
+
+	logrus.Warnf("Local controller in match %s was unable to end its turn", m.ID)
+
+}
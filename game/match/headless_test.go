@@ -0,0 +1,145 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"duel-masters/server"
+	"testing"
+	"time"
+)
+
+const burningMane = "1d72eb3e-5185-449a-a16f-391bd2338343"
+
+// attack charges mana, plays every creature it can and attacks the opponent with the
+// creatures that are able to
+func attack(m *match.Match, p *match.PlayerReference) {
+
+	legal := m.LegalActions(p.Player)
+
+	if len(legal.Charge) > 0 {
+		m.ChargeMana(p, legal.Charge[0].ID)
+	}
+
+	for len(m.LegalActions(p.Player).Play) > 0 {
+		m.PlayCard(p, m.LegalActions(p.Player).Play[0].ID)
+	}
+
+	for _, a := range m.LegalActions(p.Player).Attacks {
+		if a.Player && !m.Ended() {
+			m.AttackPlayer(p, a.Card.ID)
+		}
+	}
+
+}
+
+// selectSuggested selects the suggested cards of a prompt, or the fewest cards it allows
+func selectSuggested(m *match.Match, p *match.PlayerReference, prompt interface{}) match.PlayerAction {
+
+	action, ok := prompt.(*server.ActionMessage)
+
+	if !ok {
+		return match.PlayerAction{Cancel: true}
+	}
+
+	if len(action.Suggested) > 0 {
+		return match.PlayerAction{Cards: action.Suggested}
+	}
+
+	cards := make([]string, 0)
+
+	for i := 0; i < action.MinSelections && i < len(action.Cards); i++ {
+		cards = append(cards, action.Cards[i].CardID)
+	}
+
+	return match.PlayerAction{Cards: cards}
+
+}
+
+// TestHeadlessMatch plays a complete match between two scripted controllers, where
+// player1 attacks every turn and player2 passes
+func TestHeadlessMatch(t *testing.T) {
+
+	matchtest.Register()
+
+	deck := make([]string, 40)
+
+	for i := range deck {
+		deck[i] = burningMane
+	}
+
+	m := match.NewHeadless("headless")
+	defer m.Dispose()
+
+	m.SetFirstPlayer("attacker")
+
+	attacker := match.NewScriptedController(attack, selectSuggested)
+	passer := match.NewScriptedController(nil, selectSuggested)
+
+	p1, _ := m.AddPlayer("attacker", "attacker", attacker)
+	p2, _ := m.AddPlayer("passer", "passer", passer)
+
+	p1.Player.CreateDeck(deck)
+	p2.Player.CreateDeck(deck)
+
+	m.Do(m.Start)
+
+	select {
+	case <-attacker.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("The match did not end")
+	}
+
+	var result *server.GameOverMessage
+
+	for _, msg := range passer.Messages() {
+		if msg, ok := msg.(server.GameOverMessage); ok {
+			result = &msg
+		}
+	}
+
+	if result == nil || result.Winner != "attacker" || result.Reason != match.ShieldsBroken {
+		t.Errorf("Expected the attacker to win by breaking the shields, got %+v", result)
+	}
+
+}
+
+// TestScriptedControllerFallsBackToDefaultAction rejects every cancellation of a prompt
+// that can't be cancelled, which is what a controller without Respond answers with
+func TestScriptedControllerFallsBackToDefaultAction(t *testing.T) {
+
+	m := match.NewHeadless("scripted")
+	defer m.Dispose()
+
+	m.AddPlayer("p1", "p1", match.NewScriptedController(nil, nil))
+	m.AddPlayer("p2", "p2", &recorder{})
+
+	rejected := 0
+	var action match.PlayerAction
+
+	m.Do(func() {
+
+		p := m.Player1.Player
+
+		m.NewAction(p, nil, 0, 1, "Select up to one card", false)
+		defer m.CloseAction(p)
+
+		for rejected < 10 {
+
+			action = p.WaitForAction()
+
+			if !action.Cancel {
+				return
+			}
+
+			rejected++
+			m.ActionWarning(p, "The prompt can't be cancelled")
+
+		}
+
+	})
+
+	if action.Cancel || rejected != 3 {
+		t.Errorf("Expected the default action after 3 rejected responses, got %+v after %v", action, rejected)
+	}
+
+}
@@ -40,7 +40,7 @@ func Search(p *Player, m *Match, containerOwner *Player, containerName string, t
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
@@ -99,7 +99,7 @@ func SearchForCnd(p *Player, m *Match, containerOwner *Player, containerName str
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
@@ -158,7 +158,7 @@ func SearchForFamily(p *Player, m *Match, containerOwner *Player, containerName
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
@@ -217,7 +217,7 @@ func Filter(p *Player, m *Match, containerOwner *Player, containerName string, t
 
 	for {
 
-		action := <-p.Action
+		action := p.WaitForAction()
 
 		if cancellable && action.Cancel {
 			break
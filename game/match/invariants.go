@@ -0,0 +1,197 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CheckInvariants enables checking the state of every match after each event has been
+// handled. Violations are logged together with a dump of the state of the match
+var CheckInvariants = false
+
+// zones are all zones a card can be in
+var zones = []string{DECK, HAND, SHIELDZONE, MANAZONE, GRAVEYARD, BATTLEZONE, SPELLZONE, HIDDENZONE}
+
+// fxStarted is called when an event starts being handled
+func (m *Match) fxStarted() {
+	atomic.AddInt32(&m.fxDepth, 1)
+}
+
+// fxFinished is called when an event has been handled. The invariants are only checked
+// once the outermost event is done, the events fired by the abilities of cards are
+// handled while the state is being changed
+func (m *Match) fxFinished() {
+
+	// the cards are detached from their players once the match has ended
+	if atomic.AddInt32(&m.fxDepth, -1) > 0 || !CheckInvariants || m.Ended() {
+		return
+	}
+
+	violations := m.Violations()
+
+	if len(violations) < 1 {
+		m.violations = ""
+		return
+	}
+
+	// the same violations are only logged once, they usually persist until the end of the match
+	summary := strings.Join(violations, ". ")
+
+	if summary == m.violations {
+		return
+	}
+
+	m.violations = summary
+
+	logrus.WithField("state", m.dump()).Errorf("Invariant violated in match %s. %s", m.ID, summary)
+
+}
+
+// Violations returns the rules the state of the match breaks:
+// every card is in exactly one zone of its owner and its Zone is that zone,
+// attached cards are in the hidden zone of the same player and attached to exactly one card,
+// only cards in the battlezone and manazone are tapped,
+// and no cards have been lost or created, so the shield, hand and deck counts add up
+func (m *Match) Violations() []string {
+
+	result := make([]string, 0)
+
+	seen := make(map[string]string)
+	attached := make(map[string]int)
+	hidden := make([]*Card, 0)
+
+	for _, ref := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if ref == nil {
+			continue
+		}
+
+		p := ref.Player
+		total := 0
+
+		for _, zone := range zones {
+
+			cards, err := p.Container(zone)
+
+			if err != nil {
+				result = append(result, err.Error())
+				continue
+			}
+
+			total += len(cards)
+
+			where := fmt.Sprintf("%s's %s", ref.Username, zone)
+
+			for _, c := range cards {
+
+				name := fmt.Sprintf("%s (%s)", c.Name, c.ID)
+
+				if other, ok := seen[c.ID]; ok {
+					result = append(result, fmt.Sprintf("%s is in %s and %s", name, other, where))
+				}
+
+				seen[c.ID] = where
+
+				if c.Zone != zone {
+					result = append(result, fmt.Sprintf("%s is in %s but its zone is %s", name, where, c.Zone))
+				}
+
+				if c.Player != p {
+					result = append(result, fmt.Sprintf("%s is in %s but belongs to another player", name, where))
+				}
+
+				if c.Tapped && zone != BATTLEZONE && zone != MANAZONE {
+					result = append(result, fmt.Sprintf("%s is tapped in %s", name, where))
+				}
+
+				if len(c.Attachments()) > 0 && zone != BATTLEZONE && zone != HIDDENZONE {
+					result = append(result, fmt.Sprintf("%s has attached cards in %s", name, where))
+				}
+
+				for _, a := range c.Attachments() {
+
+					attached[a.ID]++
+
+					if a.Player != p {
+						result = append(result, fmt.Sprintf("%s is attached to %s but belongs to another player", a.Name, name))
+					}
+
+					if a.Zone != HIDDENZONE {
+						result = append(result, fmt.Sprintf("%s is attached to %s but is in the %s", a.Name, name, a.Zone))
+					}
+
+				}
+
+				if zone == HIDDENZONE {
+					hidden = append(hidden, c)
+				}
+
+			}
+
+		}
+
+		if total != p.cards {
+			result = append(result, fmt.Sprintf("%s has %v cards but got %v", ref.Username, total, p.cards))
+		}
+
+	}
+
+	for _, c := range hidden {
+		if attached[c.ID] != 1 {
+			result = append(result, fmt.Sprintf("%s (%s) is in the hidden zone and attached to %v cards", c.Name, c.ID, attached[c.ID]))
+		}
+	}
+
+	return result
+
+}
+
+// dump returns a description of the state of the match with every card of both players
+func (m *Match) dump() string {
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "match %s, turn %v of player%v, step %T\n", m.ID, m.turns, m.Turn, m.Step)
+
+	for _, ref := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if ref == nil {
+			continue
+		}
+
+		p := ref.Player
+
+		fmt.Fprintf(&b, "%s (player%v), %v cards, charged mana: %v, pending prompt: %v\n", ref.Username, p.Turn, p.cards, p.HasChargedMana, m.pendingPrompt(p) != nil)
+
+		for _, zone := range zones {
+
+			cards, _ := p.Container(zone)
+
+			fmt.Fprintf(&b, "  %s (%v)\n", zone, len(cards))
+
+			for _, c := range cards {
+
+				fmt.Fprintf(&b, "    %s %s zone=%s tapped=%v", c.ID, c.Name, c.Zone, c.Tapped)
+
+				for _, a := range c.Attachments() {
+					fmt.Fprintf(&b, " attached=%s", a.ID)
+				}
+
+				for _, condition := range c.Conditions() {
+					fmt.Fprintf(&b, " %s", condition.ID)
+				}
+
+				fmt.Fprintf(&b, "\n")
+
+			}
+
+		}
+
+	}
+
+	return b.String()
+
+}
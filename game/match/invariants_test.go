@@ -0,0 +1,63 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"strings"
+	"testing"
+)
+
+func TestViolationsEvolution(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{
+			Hand:       []string{armoredCannonBalbaro},
+			Manazone:   []string{brawlerZyler, brawlerZyler, brawlerZyler},
+			Battlezone: []string{brawlerZyler},
+		},
+		matchtest.Board{Shieldzone: []string{brawlerZyler}},
+	)
+
+	if v := h.Match.Violations(); len(v) > 0 {
+		t.Fatalf("Expected no violations before playing a card, got %v", v)
+	}
+
+	h.P1.Respond(matchtest.SelectFirst(3), matchtest.Select(brawlerZyler))
+	h.P1.Play(armoredCannonBalbaro)
+
+	h.P1.AssertZone(match.HIDDENZONE, brawlerZyler)
+
+	if v := h.Match.Violations(); len(v) > 0 {
+		t.Errorf("Expected no violations after evolving a creature, got %v", v)
+	}
+
+}
+
+func TestViolations(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{brawlerZyler}, Battlezone: []string{brawlerZyler}},
+		matchtest.Board{Shieldzone: []string{brawlerZyler}},
+	)
+
+	hand := h.P1.Card(match.HAND, brawlerZyler)
+	hand.Tapped = true
+	hand.Zone = match.GRAVEYARD
+
+	h.P1.Card(match.BATTLEZONE, brawlerZyler).Attach(h.P2.Card(match.SHIELDZONE, brawlerZyler))
+
+	expected := []string{
+		"is in player1's hand but its zone is graveyard",
+		"is tapped in player1's hand",
+		"is attached to Brawler Zyler",
+	}
+
+	violations := strings.Join(h.Match.Violations(), ". ")
+
+	for _, e := range expected {
+		if !strings.Contains(violations, e) {
+			t.Errorf("Expected a violation containing %q, got %s", e, violations)
+		}
+	}
+
+}
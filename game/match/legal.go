@@ -0,0 +1,135 @@
+package match
+
+import "duel-masters/game/cnd"
+
+// Attack is a creature that can attack and the targets it can attack
+type Attack struct {
+	Card      *Card
+	Player    bool
+	Creatures []*Card
+}
+
+// LegalActions holds the moves a player can make, each of them is a message the
+// player can send: add_to_manazone, add_to_playzone, attack_player, attack_creature and end_turn
+type LegalActions struct {
+	Charge  []*Card
+	Play    []*Card
+	Attacks []Attack
+	EndTurn bool
+}
+
+// Empty returns true if the player can not make any moves
+func (l LegalActions) Empty() bool {
+	return len(l.Charge) < 1 && len(l.Play) < 1 && len(l.Attacks) < 1 && !l.EndTurn
+}
+
+// LegalActions returns the moves the player can make right now. There are none outside of
+// the player's turn, after the match ended or while either player has to respond to a prompt.
+// Abilities that interrupt a move when it is made are not taken into account, only the
+// conditions of the cards are
+func (m *Match) LegalActions(p *Player) LegalActions {
+
+	legal := LegalActions{
+		Charge:  make([]*Card, 0),
+		Play:    make([]*Card, 0),
+		Attacks: make([]Attack, 0),
+	}
+
+	opponent := m.Opponent(p)
+
+	if !m.Started || m.Ended() || !m.IsPlayerTurn(p) || m.PromptState(p) != PromptIdle {
+		return legal
+	}
+
+	legal.EndTurn = true
+
+	hand, err := p.Container(HAND)
+
+	if err != nil {
+		return legal
+	}
+
+	if !p.HasChargedMana && p.CanChargeMana {
+		legal.Charge = append(legal.Charge, hand...)
+	}
+
+	// creatures can not be summoned and spells can not be cast after attacking
+	if _, ok := m.Step.(*AttackStep); !ok {
+		legal.Play = m.playable(p, hand)
+	}
+
+	battlezone, err := p.Container(BATTLEZONE)
+
+	if err != nil {
+		return legal
+	}
+
+	targets, err := opponent.Container(BATTLEZONE)
+
+	if err != nil {
+		return legal
+	}
+
+	for _, c := range battlezone {
+
+		if c.Tapped || c.HasCondition(cnd.SummoningSickness) {
+			continue
+		}
+
+		attack := Attack{
+			Card:      c,
+			Player:    !c.HasCondition(cnd.CantAttackPlayers),
+			Creatures: make([]*Card, 0),
+		}
+
+		if !c.HasCondition(cnd.CantAttackCreatures) {
+			for _, target := range targets {
+				if target.Tapped || c.HasCondition(cnd.AttackUntapped) {
+					attack.Creatures = append(attack.Creatures, target)
+				}
+			}
+		}
+
+		if attack.Player || len(attack.Creatures) > 0 {
+			legal.Attacks = append(legal.Attacks, attack)
+		}
+
+	}
+
+	return legal
+
+}
+
+// playable returns the cards the player can pay for with their untapped mana,
+// evolution creatures also need a creature of their race to evolve from
+func (m *Match) playable(p *Player, hand []*Card) []*Card {
+
+	result := make([]*Card, 0)
+
+	untapped := make([]*Card, 0)
+
+	if manazone, err := p.Container(MANAZONE); err == nil {
+		for _, c := range manazone {
+			if !c.Tapped {
+				untapped = append(untapped, c)
+			}
+		}
+	}
+
+	for _, c := range hand {
+
+		if !p.CanPlayCard(c, untapped) {
+			continue
+		}
+
+		if c.HasCondition(cnd.Evolution) && !ContainerHas(p, BATTLEZONE, func(x *Card) bool { return x.Family == c.Family }) {
+			continue
+		}
+
+		result = append(result, c)
+
+	}
+
+	return result
+
+}
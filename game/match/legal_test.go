@@ -0,0 +1,125 @@
+package match_test
+
+import (
+	"duel-masters/game/cnd"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+const (
+	brawlerZyler         = "5370bad9-1260-455e-8120-ea89badc7eaf"
+	armoredCannonBalbaro = "24353d06-89ef-4867-9513-485750d01e10"
+)
+
+func ids(cards []*match.Card) []string {
+
+	result := make([]string, 0)
+
+	for _, c := range cards {
+		result = append(result, c.ImageID)
+	}
+
+	return result
+
+}
+
+func TestLegalActions(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{
+			Hand:     []string{armoredCannonBalbaro, brawlerZyler},
+			Manazone: []string{brawlerZyler, brawlerZyler, brawlerZyler},
+		},
+		matchtest.Board{Battlezone: []string{phantomFish}},
+	)
+
+	legal := h.Match.LegalActions(h.P1.Player)
+
+	if len(legal.Charge) != 2 || !legal.EndTurn {
+		t.Errorf("Expected both cards in hand to be chargeable and the turn to be endable, got %v %v", ids(legal.Charge), legal.EndTurn)
+	}
+
+	if got := ids(legal.Play); len(got) != 1 || got[0] != brawlerZyler {
+		t.Errorf("Expected only Brawler Zyler to be playable without evolution bait, got %v", got)
+	}
+
+	if !h.Match.LegalActions(h.P2.Player).Empty() {
+		t.Error("Expected player2 not to have any moves outside of their turn")
+	}
+
+	h.P1.Charge(brawlerZyler)
+
+	legal = h.Match.LegalActions(h.P1.Player)
+
+	if len(legal.Charge) != 0 {
+		t.Errorf("Expected no cards to be chargeable after charging mana, got %v", ids(legal.Charge))
+	}
+
+	if len(legal.Play) != 0 {
+		t.Errorf("Expected Armored Cannon Balbaro not to be playable without bait, got %v", ids(legal.Play))
+	}
+
+}
+
+func TestLegalActionsEvolutionBait(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{
+			Hand:       []string{armoredCannonBalbaro},
+			Manazone:   []string{brawlerZyler, brawlerZyler, brawlerZyler},
+			Battlezone: []string{brawlerZyler},
+		},
+		matchtest.Board{},
+	)
+
+	if got := ids(h.Match.LegalActions(h.P1.Player).Play); len(got) != 1 || got[0] != armoredCannonBalbaro {
+		t.Errorf("Expected Armored Cannon Balbaro to be playable with a human in the battlezone, got %v", got)
+	}
+
+}
+
+func TestLegalActionsAttacks(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Battlezone: []string{brawlerZyler, aquaHulcus, phantomFish, horridWorm}},
+		matchtest.Board{Battlezone: []string{horridWorm, phantomFish}},
+	)
+
+	zyler := h.P1.Card(match.BATTLEZONE, brawlerZyler)
+	hulcus := h.P1.Card(match.BATTLEZONE, aquaHulcus)
+
+	worm := h.P2.Card(match.BATTLEZONE, horridWorm)
+	worm.Tapped = true
+
+	h.P1.Card(match.BATTLEZONE, horridWorm).AddCondition(cnd.SummoningSickness, nil, nil)
+	hulcus.AddCondition(cnd.CantAttackPlayers, true, hulcus.ID)
+
+	attacks := h.Match.LegalActions(h.P1.Player).Attacks
+
+	if len(attacks) != 2 {
+		t.Fatalf("Expected 2 creatures to be able to attack, got %v", len(attacks))
+	}
+
+	for _, attack := range attacks {
+
+		switch attack.Card {
+
+		case zyler:
+			if !attack.Player || len(attack.Creatures) != 1 || attack.Creatures[0] != worm {
+				t.Errorf("Expected Brawler Zyler to be able to attack the player and the tapped creature, got %v %v", attack.Player, ids(attack.Creatures))
+			}
+
+		case hulcus:
+			if attack.Player || len(attack.Creatures) != 1 {
+				t.Errorf("Expected the creature that can't attack players to only attack the tapped creature, got %v %v", attack.Player, ids(attack.Creatures))
+			}
+
+		default:
+			t.Errorf("Expected %s not to be able to attack", attack.Card.Name)
+
+		}
+
+	}
+
+}
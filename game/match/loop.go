@@ -0,0 +1,260 @@
+package match
+
+import (
+	"fmt"
+	"time"
+
+	"duel-masters/metrics"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxAutomaticResponses is how many prompts are answered on behalf of the players during
+// a single event after the match was stopped, before the event is given up
+const maxAutomaticResponses = 100
+
+// commands are the messages from players that make a move in the game. They are rejected
+// while a prompt is pending, so that they don't interleave with the move that sent the prompt
+var commands = map[string]bool{
+	"choose_deck":     true,
+	"add_to_manazone": true,
+	"add_to_playzone": true,
+	"attack_player":   true,
+	"attack_creature": true,
+	"end_turn":        true,
+}
+
+// event is a function that is run by the loop of the match
+type event struct {
+	fn func()
+	// command events are postponed while the match is waiting for a response
+	command bool
+	// done is closed once the event was handled, it is nil if nobody waits for it
+	done chan struct{}
+}
+
+// startLoop starts the loop of the match unless it is already running
+func (m *Match) startLoop() {
+	m.loopOnce.Do(func() { go m.loop() })
+}
+
+// loop handles the events of the match one at a time until the match is stopped.
+// Inbound messages, timers, disconnects and the moves of local controllers all run
+// here, so the state of the match is only ever changed by this goroutine
+func (m *Match) loop() {
+
+	defer close(m.stopped)
+	defer m.dispose()
+
+	for !m.stopping {
+		m.next()
+		m.flush()
+		m.closeStalePrompts()
+	}
+
+}
+
+// next waits for the next event and handles it
+func (m *Match) next() {
+
+	select {
+
+	case e := <-m.events:
+		m.handle(e)
+
+	case <-m.ticker.C:
+		m.exec(m.tick)
+
+	case <-m.clockTicker.C:
+		m.exec(m.checkClock)
+		m.exec(m.checkDisconnected)
+		m.exec(m.checkReserved)
+
+	}
+
+}
+
+// handle runs the event. Commands are queued while the loop is waiting for a response
+// and run once the prompt has been answered
+func (m *Match) handle(e event) {
+
+	if e.command && m.waiting > 0 {
+		m.queued = append(m.queued, e)
+		return
+	}
+
+	m.exec(e.fn)
+
+	if e.done != nil {
+		close(e.done)
+	}
+
+}
+
+// exec runs f and recovers from panics, so a broken card does not stop the match
+func (m *Match) exec(f func()) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from match event. %v", r)
+			metrics.Panics.Inc("match_event")
+		}
+	}()
+
+	f()
+
+}
+
+// flush runs the commands that were queued while the loop was waiting for a response
+func (m *Match) flush() {
+
+	m.automatic = 0
+
+	for len(m.queued) > 0 && !m.stopping {
+		e := m.queued[0]
+		m.queued = m.queued[1:]
+		m.handle(e)
+	}
+
+}
+
+// queue runs f once the current event has been handled and no prompt is pending
+func (m *Match) queue(f func()) {
+	m.queued = append(m.queued, event{fn: f, command: true})
+}
+
+// post adds the event to the loop, false is returned if the match was closed
+func (m *Match) post(e event) bool {
+
+	m.startLoop()
+
+	select {
+	case m.events <- e:
+		return true
+	case <-m.stopped:
+		return false
+	}
+
+}
+
+// Do runs f in the loop of the match and waits for it to return. Everything that
+// happens outside of the loop, like the moves of local controllers, has to go through
+// Do so it does not race with the match. f is postponed while a prompt is pending and
+// not run at all if the match is closed in the meantime.
+// Do must not be called from within the loop, e.g. from a controller's Send
+func (m *Match) Do(f func()) {
+
+	e := event{fn: f, command: true, done: make(chan struct{})}
+
+	if !m.post(e) {
+		return
+	}
+
+	select {
+	case <-e.done:
+	case <-m.stopped:
+	}
+
+}
+
+// Dispose stops the match and waits until it has been closed
+func (m *Match) Dispose() {
+
+	m.post(event{fn: m.stop})
+
+	<-m.stopped
+
+}
+
+// stop ends the loop once the current event has been handled. Prompts that are still
+// pending are answered on behalf of the players so that the event can finish
+func (m *Match) stop() {
+
+	logrus.Debugf("Closing match %s", m.ID)
+
+	m.ending = true
+	m.stopping = true
+	m.cancelling = true
+
+}
+
+// tick closes the match if it was not started within 10 minutes of creation or the time
+// to ask for a rematch is over, and pings the players
+func (m *Match) tick() {
+
+	if !m.Started && m.created < time.Now().Unix()-60*10 {
+		m.stop()
+		return
+	}
+
+	if !m.ended.IsZero() && time.Since(m.ended) > RematchTime {
+
+		// the player who did not choose in time goes first in the next game of the series
+		if m.chooser != "" {
+			m.nextSeriesGame(m.chooser)
+		}
+
+		m.stop()
+		return
+
+	}
+
+	m.ping()
+
+}
+
+// WaitForAction waits for the player to respond to the prompt they were sent. The match
+// keeps handling other events in the meantime, such as chat messages, timers and the
+// response itself
+func (p *Player) WaitForAction() PlayerAction {
+	return p.match.waitForAction(p)
+}
+
+func (m *Match) waitForAction(p *Player) PlayerAction {
+
+	m.waiting++
+	defer func() { m.waiting-- }()
+
+	for {
+
+		if p.response != nil {
+			action := *p.response
+			p.response = nil
+			return action
+		}
+
+		if m.cancelling {
+
+			m.automatic++
+
+			if m.automatic > maxAutomaticResponses {
+				panic(fmt.Sprintf("%s's prompts could not be closed after %v responses", p.Username(), maxAutomaticResponses))
+			}
+
+			return m.DefaultAction(p)
+
+		}
+
+		m.next()
+
+	}
+
+}
+
+// Respond answers the prompt the player was sent, it is used by local controllers
+func (p *Player) Respond(action PlayerAction) {
+	p.match.post(event{fn: func() { p.match.respond(p, action) }})
+}
+
+// respond answers the player's prompt, the response is returned by WaitForAction.
+// Responses are dropped if the player has no pending prompt
+func (m *Match) respond(p *Player, action PlayerAction) {
+
+	if !m.HasPendingPrompt(p) {
+		logrus.Debugf("Dropped the response of %s in match %s without a pending prompt", p.Username(), m.ID)
+		return
+	}
+
+	p.response = &action
+
+}
@@ -0,0 +1,128 @@
+package match_test
+
+import (
+	"duel-masters/game/bot"
+	"duel-masters/game/cards"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testDeck returns a deck of 40 cards from the first set, taking every card of the set in turn
+func testDeck() []string {
+
+	matchtest.Register()
+
+	uids := make([]string, 0)
+
+	for uid := range *cards.Sets["dm-01"] {
+		uids = append(uids, uid)
+	}
+
+	sort.Strings(uids)
+
+	deck := make([]string, 0)
+
+	for i := 0; i < 40; i++ {
+		deck = append(deck, uids[i%len(uids)])
+	}
+
+	return deck
+
+}
+
+// TestConcurrentMatches plays several bot matches at the same time while other goroutines
+// chat, broadcast the state and close some of the matches. Run with -race to find updates
+// that don't go through the loop of the match
+func TestConcurrentMatches(t *testing.T) {
+
+	deck := testDeck()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+
+		m := match.NewHeadless("concurrent")
+		m.SetSeed(int64(i + 1))
+
+		b1 := bot.New()
+		b1.Delay = 0
+
+		b2 := bot.New()
+		b2.Delay = time.Millisecond
+
+		p1, _ := m.AddPlayer("bot1", "bot1", b1)
+		p2, _ := m.AddPlayer("bot2", "bot2", b2)
+
+		p1.Player.CreateDeck(deck)
+		p2.Player.CreateDeck(deck)
+
+		m.Do(m.Start)
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				m.Do(m.BroadcastState)
+				m.Do(func() { m.Chat("Server", "Hello") })
+			}
+		}()
+
+		go func(dispose bool) {
+			defer wg.Done()
+
+			if dispose {
+				time.Sleep(20 * time.Millisecond)
+				m.Dispose()
+				return
+			}
+
+			select {
+			case <-b1.Done():
+			case <-time.After(30 * time.Second):
+				t.Error("The match did not end")
+			}
+
+			m.Dispose()
+		}(i%2 == 0)
+
+	}
+
+	wg.Wait()
+
+}
+
+// TestDisposeWithPendingPrompt closes a match while a prompt is pending
+func TestDisposeWithPendingPrompt(t *testing.T) {
+
+	m := match.NewHeadless("dispose")
+
+	p1, _ := m.AddPlayer("p1", "p1", &recorder{})
+	m.AddPlayer("p2", "p2", &recorder{})
+
+	done := make(chan struct{})
+
+	go m.Do(func() {
+		defer close(done)
+
+		m.NewAction(p1.Player, nil, 1, 1, "Select a card", false)
+		p1.Player.WaitForAction()
+		m.CloseAction(p1.Player)
+	})
+
+	time.Sleep(10 * time.Millisecond)
+
+	m.Dispose()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the pending prompt to be answered when the match is closed")
+	}
+
+}
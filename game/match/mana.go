@@ -0,0 +1,182 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidatePayment returns an error if the mana cards are not a legal payment for the card.
+// Exactly the mana cost has to be paid with untapped cards from the player's manazone, and
+// each civilization the card requires has to be paid with a different mana card
+func (p *Player) ValidatePayment(card *Card, mana []*Card) error {
+
+	cost := p.match.GetManaCost(card)
+
+	if len(mana) != cost {
+		return fmt.Errorf("You must select %v cards from your manazone to play %s", cost, card.Name)
+	}
+
+	selected := make(map[string]bool)
+
+	for _, c := range mana {
+
+		if c.Player != p || c.Zone != MANAZONE {
+			return errors.New("You can only pay with cards from your manazone")
+		}
+
+		if c.Tapped {
+			return errors.New("You can only pay with untapped mana")
+		}
+
+		if selected[c.ID] {
+			return errors.New("Each mana card can only be used once")
+		}
+
+		selected[c.ID] = true
+
+	}
+
+	if _, ok := payCivs(card.ManaRequirement, mana); !ok {
+		return fmt.Errorf("You must select at least 1 %s card to play %s", strings.Join(card.ManaRequirement, " and 1 "), card.Name)
+	}
+
+	return nil
+
+}
+
+// SuggestPayment returns a legal payment for the card from the player's untapped mana.
+// Single civilization cards of the civilizations the player has the most of are used
+// first, keeping the mana that is harder to replace for later
+func (p *Player) SuggestPayment(card *Card) ([]*Card, error) {
+
+	manazone, err := p.Container(MANAZONE)
+
+	if err != nil {
+		return nil, err
+	}
+
+	untapped := make([]*Card, 0)
+	counts := make(map[string]int)
+
+	for _, c := range manazone {
+
+		if c.Tapped {
+			continue
+		}
+
+		untapped = append(untapped, c)
+
+		for _, civ := range c.Civilizations() {
+			counts[civ]++
+		}
+
+	}
+
+	cost := p.match.GetManaCost(card)
+
+	if cost > len(untapped) {
+		return nil, errors.New("Not enough untapped mana")
+	}
+
+	// The least common civilization of a card decides how hard it is to replace
+	rarity := func(c *Card) int {
+
+		min := -1
+
+		for _, civ := range c.Civilizations() {
+			if min < 0 || counts[civ] < min {
+				min = counts[civ]
+			}
+		}
+
+		return min
+
+	}
+
+	sort.SliceStable(untapped, func(i, j int) bool {
+
+		a, b := untapped[i], untapped[j]
+
+		if len(a.Civilizations()) != len(b.Civilizations()) {
+			return len(a.Civilizations()) < len(b.Civilizations())
+		}
+
+		return rarity(a) > rarity(b)
+
+	})
+
+	payment, ok := payCivs(card.ManaRequirement, untapped)
+
+	if !ok {
+		return nil, errors.New("The required civilizations can not be paid")
+	}
+
+	for _, c := range untapped {
+
+		if len(payment) >= cost {
+			break
+		}
+
+		if !AssertCardsIn(payment, c.ID) {
+			payment = append(payment, c)
+		}
+
+	}
+
+	return payment, nil
+
+}
+
+// payCivs returns a different mana card for each of the required civilizations,
+// preferring the cards that come first. False is returned if it is not possible
+func payCivs(required []string, mana []*Card) ([]*Card, bool) {
+
+	// paying holds the index of the civilization each mana card is used for
+	paying := make(map[*Card]int)
+
+	var assign func(i int, visited map[*Card]bool) bool
+
+	// assign finds a mana card for the civilization, moving the civilizations that
+	// are already paid to other mana cards when that makes room for it
+	assign = func(i int, visited map[*Card]bool) bool {
+
+		for _, c := range mana {
+
+			if visited[c] || !c.HasCiv(required[i]) {
+				continue
+			}
+
+			visited[c] = true
+
+			j, used := paying[c]
+
+			if !used || assign(j, visited) {
+				paying[c] = i
+				return true
+			}
+
+		}
+
+		return false
+
+	}
+
+	for i := range required {
+		if !assign(i, make(map[*Card]bool)) {
+			return nil, false
+		}
+	}
+
+	result := make([]*Card, 0)
+
+	for _, c := range mana {
+		if _, ok := paying[c]; ok {
+			result = append(result, c)
+		}
+	}
+
+	return result, true
+
+}
@@ -0,0 +1,134 @@
+package match_test
+
+import (
+	"duel-masters/game/civ"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+// payment returns a harness where player1 has a Phantom Fish in the hand, costing 3,
+// and 4 water cards in the manazone
+func payment(t *testing.T) (*matchtest.Harness, *match.Card, []*match.Card) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{phantomFish}, Manazone: []string{phantomFish, phantomFish, phantomFish, phantomFish}},
+		matchtest.Board{},
+	)
+
+	mana, err := h.P1.Player.Container(match.MANAZONE)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h, h.P1.Card(match.HAND, phantomFish), mana
+
+}
+
+func TestValidatePayment(t *testing.T) {
+
+	h, card, mana := payment(t)
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:3]); err != nil {
+		t.Errorf("Expected 3 water cards to pay for the card, got %v", err)
+	}
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:2]); err == nil {
+		t.Error("Expected an error when paying less than the cost")
+	}
+
+	if err := h.P1.Player.ValidatePayment(card, []*match.Card{mana[0], mana[0], mana[1]}); err == nil {
+		t.Error("Expected an error when using the same mana card twice")
+	}
+
+	mana[2].Tapped = true
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:3]); err == nil {
+		t.Error("Expected an error when paying with tapped mana")
+	}
+
+	mana[2].Tapped = false
+	card.ManaRequirement = []string{civ.Water, civ.Fire}
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:3]); err == nil {
+		t.Error("Expected an error when a required civilization is not paid")
+	}
+
+	// A water and fire card pays for fire, as long as another card pays for water
+	mana[0].AddCivilizations(civ.Fire)
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:3]); err != nil {
+		t.Errorf("Expected the multi civilization card to pay for fire, got %v", err)
+	}
+
+	card.ManaRequirement = []string{civ.Water, civ.Fire, civ.Light}
+
+	if err := h.P1.Player.ValidatePayment(card, mana[:3]); err == nil {
+		t.Error("Expected an error when a card would have to pay for two civilizations")
+	}
+
+}
+
+func TestSuggestPayment(t *testing.T) {
+
+	h, card, mana := payment(t)
+
+	mana[0].AddCivilizations(civ.Fire)
+
+	suggested, err := h.P1.Player.SuggestPayment(card)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(suggested) != 3 || match.AssertCardsIn(suggested, mana[0].ID) {
+		t.Errorf("Expected the 3 single civilization cards to be suggested, got %v", suggested)
+	}
+
+	card.ManaRequirement = []string{civ.Fire, civ.Water}
+
+	suggested, err = h.P1.Player.SuggestPayment(card)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.P1.Player.ValidatePayment(card, suggested); err != nil {
+		t.Errorf("Expected the suggested payment to be legal, got %v", err)
+	}
+
+	card.ManaRequirement = []string{civ.Fire, civ.Light}
+
+	if _, err := h.P1.Player.SuggestPayment(card); err == nil {
+		t.Error("Expected no payment when a required civilization is missing")
+	}
+
+	if h.P1.Player.CanPlayCard(card, mana) {
+		t.Error("Expected the card not to be playable when a required civilization is missing")
+	}
+
+}
+
+func TestCivilizations(t *testing.T) {
+
+	_, card, _ := payment(t)
+
+	card.AddCivilizations(civ.Fire, civ.Water)
+
+	if civs := card.Civilizations(); len(civs) != 2 || civs[0] != civ.Water || civs[1] != civ.Fire {
+		t.Errorf("Expected the civilizations [water fire], got %v", civs)
+	}
+
+	// Civ is always one of the civilizations, even when changed afterwards
+	card.Civ = civ.Light
+
+	if !card.HasCiv(civ.Light) || !card.HasCiv(civ.Fire) || !card.HasCiv(civ.Water) {
+		t.Errorf("Expected the card to be light, fire and water, got %v", card.Civilizations())
+	}
+
+	if civs := card.Civilizations(); len(civs) != 3 || civs[0] != civ.Light {
+		t.Errorf("Expected 3 civilizations starting with light, got %v", civs)
+	}
+
+}
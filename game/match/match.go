@@ -1,21 +1,22 @@
 package match
 
 import (
-	"context"
 	"duel-masters/db"
 	"duel-masters/game/cnd"
+	"duel-masters/game/format"
+	"duel-masters/metrics"
 	"duel-masters/server"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/ventu-io/go-shortid"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 var matches = make(map[string]*Match)
@@ -40,6 +41,7 @@ type Match struct {
 	ID                string           `json:"id"`
 	MatchName         string           `json:"name"`
 	HostID            string           `json:"-"`
+	GuestID           string           `json:"-"`
 	Player1           *PlayerReference `json:"-"`
 	Player2           *PlayerReference `json:"-"`
 	spectators        Spectators       `json:"-"`
@@ -50,11 +52,50 @@ type Match struct {
 	Step              interface{}
 
 	created     int64
+	started     int64
+	turns       int
 	ending      bool
 	closed      bool
 	isFirstTurn bool
-
-	quit chan bool
+	resultSaved bool
+	result      *db.Match
+	headless    bool
+	computer    bool
+	forfeiting  bool
+
+	// ended is when the game ended, the match stays open until then for a rematch
+	ended     time.Time
+	first     string
+	drawOffer *drawOffer
+	rematches map[string]bool
+	previous  map[string]string
+	series    *Series
+	chooser   string
+	joinBy    time.Time
+
+	seed   int64
+	rng    *rand.Rand
+	replay *recorder
+
+	fxDepth     int32
+	startedFlag int32
+	violations  string
+
+	clock       Clock
+	clockState  clockState
+	now         func() time.Time
+	promptMutex sync.Mutex
+
+	events      chan event
+	queued      []event
+	waiting     int
+	automatic   int
+	cancelling  bool
+	stopping    bool
+	loopOnce    sync.Once
+	stopped     chan struct{}
+	ticker      *time.Ticker
+	clockTicker *time.Ticker
 }
 
 // Matches returns a list of the current matches
@@ -71,12 +112,32 @@ func Matches() []string {
 // New returns a new match object
 func New(matchName string, hostID string, visible bool) *Match {
 
+	m := newMatch(matchName, hostID, visible)
+
+	matchesMutex.Lock()
+
+	matches[m.ID] = m
+
+	matchesMutex.Unlock()
+
+	m.startLoop()
+
+	logrus.Debugf("Created match %s", m.ID)
+
+	return m
+
+}
+
+func newMatch(matchName string, hostID string, visible bool) *Match {
+
 	id, err := shortid.Generate()
 
 	if err != nil {
 		id = uuid.New().String()
 	}
 
+	seed := newSeed()
+
 	m := &Match{
 		ID:                id,
 		MatchName:         matchName,
@@ -90,19 +151,19 @@ func New(matchName string, hostID string, visible bool) *Match {
 		created:     time.Now().Unix(),
 		ending:      false,
 		isFirstTurn: true,
+		rematches:   make(map[string]bool),
+		previous:    make(map[string]string),
 
-		quit: make(chan bool),
-	}
-
-	matchesMutex.Lock()
-
-	matches[id] = m
-
-	matchesMutex.Unlock()
-
-	go m.startTicker()
+		seed:   seed,
+		rng:    newRand(seed),
+		replay: newRecorder(),
 
-	logrus.Debugf("Created match %s", id)
+		events:      make(chan event, 64),
+		stopped:     make(chan struct{}),
+		ticker:      time.NewTicker(10 * time.Second),
+		clockTicker: time.NewTicker(time.Second),
+		now:         time.Now,
+	}
 
 	return m
 
@@ -110,7 +171,7 @@ func New(matchName string, hostID string, visible bool) *Match {
 
 // Name just returns "match", obligatory for a hub
 func (m *Match) Name() string {
-	return "match"
+	return server.MatchHub
 }
 
 // LobbyMatchList returns the channel to receive match list updates
@@ -132,7 +193,7 @@ func UpdateMatchList() {
 			continue
 		}
 
-		if match.ending {
+		if match.ending || match.Player1 == nil {
 			continue
 		}
 
@@ -165,44 +226,8 @@ func UpdateMatchList() {
 
 }
 
-func (m *Match) startTicker() {
-
-	ticker := time.NewTicker(10 * time.Second) // tick every 10 seconds
-
-	defer ticker.Stop()
-	defer m.Dispose()
-	defer func() {
-		if r := recover(); r != nil {
-			logrus.Warnf("Recovered from match ticker. %v", r)
-		}
-	}()
-
-	for {
-
-		select {
-		case <-m.quit:
-			{
-				logrus.Debugf("Closing match %s", m.ID)
-				m.ending = true
-				return
-			}
-		case <-ticker.C:
-			{
-
-				// Close the match if it was not started within 10 minutes of creation
-				if !m.Started && m.created < time.Now().Unix()-60*10 {
-					logrus.Debugf("Closing match %s", m.ID)
-					return
-				}
-
-			}
-		}
-
-	}
-}
-
-// Dispose closes the match, disconnects the clients and removes all references to it
-func (m *Match) Dispose() {
+// dispose closes the match, disconnects the clients and removes all references to it
+func (m *Match) dispose() {
 
 	if m.closed {
 		return
@@ -215,9 +240,17 @@ func (m *Match) Dispose() {
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Warningf("Recovered from disposing a match. %v", r)
+			metrics.Panics.Inc("match_dispose")
 		}
 	}()
 
+	// the match was closed without being decided, i.e. both players left
+	m.saveResult(nil, Disconnect)
+
+	m.observeDuration()
+
+	m.saveReplay()
+
 	m.spectators.Lock()
 	defer m.spectators.Unlock()
 	for _, spectator := range m.spectators.users {
@@ -228,34 +261,45 @@ func (m *Match) Dispose() {
 		spectator.Socket = nil
 	}
 
-	if m.Player1 != nil {
-		m.Player1.Socket.Close()
-		m.Player1.Player.Dispose()
-	}
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if p == nil {
+			continue
+		}
+
+		if p.Controller != nil {
+			p.Controller.Close()
+		}
+
+		p.Player.Dispose()
 
-	if m.Player2 != nil {
-		m.Player2.Socket.Close()
-		m.Player2.Player.Dispose()
 	}
 
-	matchesMutex.Lock()
+	m.ticker.Stop()
+	m.clockTicker.Stop()
 
-	close(m.quit)
+	matchesMutex.Lock()
 
 	delete(matches, m.ID)
 
 	matchesMutex.Unlock()
 
+	m.releaseSeats()
+
 	logrus.Debugf("Closed match with id %s", m.ID)
 
-	UpdateMatchList()
+	if !m.headless {
+		UpdateMatchList()
+	}
 
 }
 
 // Find returns a match with the specified id, or an error
 func Find(id string) (*Match, error) {
 
+	matchesMutex.Lock()
 	m := matches[id]
+	matchesMutex.Unlock()
 
 	if m != nil {
 		return m, nil
@@ -269,6 +313,16 @@ func (m *Match) IsPlayerTurn(p *Player) bool {
 	return m.Turn == p.Turn
 }
 
+// Ended returns true if the match has ended or is being closed
+func (m *Match) Ended() bool {
+	return m.ending || m.closed
+}
+
+// Turns returns the number of turns that have been started in the match
+func (m *Match) Turns() int {
+	return m.turns
+}
+
 // CurrentPlayer returns either player1 or player2 based on who's turn it currently is
 func (m *Match) CurrentPlayer() *PlayerReference {
 
@@ -283,11 +337,11 @@ func (m *Match) CurrentPlayer() *PlayerReference {
 // PlayerForSocket returns the player ref for a given socker or an error if the socket is not p1 or p2
 func (m *Match) PlayerForSocket(s *server.Socket) (*PlayerReference, error) {
 
-	if m.Player1.Socket == s {
+	if m.Player1.Controller == s {
 		return m.Player1, nil
 	}
 
-	if m.Player2.Socket == s {
+	if m.Player2.Controller == s {
 		return m.Player2, nil
 	}
 
@@ -359,6 +413,43 @@ func (m *Match) GetPower(card *Card, isAttacking bool) int {
 
 }
 
+// GetManaCost returns the mana cost of a given card after applying conditions and
+// effects. Increases are applied before reductions, and the cost is never less than 1
+func (m *Match) GetManaCost(card *Card) int {
+
+	e := &GetManaCostEvent{
+		Card: card,
+	}
+
+	for _, condition := range card.Conditions() {
+
+		val, ok := condition.Val.(int)
+
+		if !ok {
+			continue
+		}
+
+		switch condition.ID {
+		case cnd.IncreasedCost:
+			e.Increase += val
+		case cnd.ReducedCost:
+			e.Reduction += val
+		}
+
+	}
+
+	m.HandleFx(NewContext(m, e))
+
+	cost := card.ManaCost + e.Increase - e.Reduction
+
+	if cost < 1 {
+		cost = 1
+	}
+
+	return cost
+
+}
+
 // CastSpell Fires a SpellCast event
 func (m *Match) CastSpell(card *Card, fromShield bool) {
 
@@ -438,7 +529,7 @@ func (m *Match) BreakShields(shields []*Card) {
 		return
 	}
 
-	m.Chat("Server", fmt.Sprintf("%v of %v's shields were broken", len(shields), m.PlayerRef(shields[0].Player).Socket.User.Username))
+	m.Chat("Server", fmt.Sprintf("%v of %v's shields were broken", len(shields), m.PlayerRef(shields[0].Player).Username))
 
 	for _, shield := range shields {
 
@@ -467,7 +558,7 @@ func (m *Match) BreakShields(shields []*Card) {
 
 			for {
 
-				action := <-card.Player.Action
+				action := card.Player.WaitForAction()
 
 				if action.Cancel {
 					m.CloseAction(card.Player)
@@ -499,8 +590,9 @@ func (m *Match) BreakShields(shields []*Card) {
 
 }
 
-// End ends the match
-func (m *Match) End(winner *Player, winnerStr string) {
+// End ends the match and stores the result with the given reason, winner is nil if the
+// game ended in a draw. Matches between users stay open for a rematch until RematchTime passed
+func (m *Match) End(winner *Player, reason string, winnerStr string) {
 
 	logrus.Debugf("Attempting to end match %s", m.ID)
 
@@ -509,6 +601,10 @@ func (m *Match) End(winner *Player, winnerStr string) {
 		return
 	}
 
+	m.ending = true
+
+	rematch := m.canRematch()
+
 	if m.Started {
 
 		m.Broadcast(server.WarningMessage{
@@ -516,9 +612,35 @@ func (m *Match) End(winner *Player, winnerStr string) {
 			Message: winnerStr,
 		})
 
+		msg := server.GameOverMessage{
+			Header:  "game_over",
+			Reason:  reason,
+			Rematch: rematch,
+		}
+
+		if winner != nil {
+			msg.Winner = m.PlayerRef(winner).Username
+		}
+
+		m.broadcastSince(rematchVersion, msg)
+
+	}
+
+	next := m.endSeriesGame(winner)
+
+	m.saveResult(winner, reason)
+
+	if !rematch && !next {
+		m.stop()
+		return
 	}
 
-	m.quit <- true
+	// The match stays open for the players to ask for a rematch or to choose who goes first
+	// in the next game of the series, the prompts that are still pending are answered on their behalf
+	m.ended = time.Now()
+	m.cancelling = true
+
+	UpdateMatchList()
 
 }
 
@@ -531,6 +653,8 @@ func (m *Match) ColorChat(sender string, message string, color string) {
 		Color:   color,
 	}
 
+	m.replay.recordChat(msg)
+
 	m.Broadcast(msg)
 }
 
@@ -539,27 +663,18 @@ func (m *Match) Chat(sender string, message string) {
 	m.ColorChat(sender, message, "#ccc")
 }
 
+// Broadcast sends a message to both players and the spectators
 func (m *Match) Broadcast(msg interface{}) {
 
-	defer func() {
-		if r := recover(); r != nil {
-			logrus.Warnf("Recovered during Broadcast(). %v", r)
-		}
-	}()
-
-	m.Player1.Socket.Send(msg)
-	m.Player2.Socket.Send(msg)
-
-	// could fail due to concurrent updates to spectators map
-	// but don't want to lock it here as broadcast will be used everywhere
-	// so recover will deal with those special occasians where it fails
-	for _, spectator := range m.spectators.users {
-		if spectator.Socket == nil {
-			continue
+	// the controller of a disconnected player is nil
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+		if p != nil && p.Controller != nil {
+			p.Controller.Send(msg)
 		}
-		spectator.Socket.Send(msg)
 	}
 
+	m.sendSpectators(msg)
+
 }
 
 // BroadcastState sends the current game's state to both players, hiding the opponent's hand
@@ -573,6 +688,16 @@ func (m *Match) BroadcastState() {
 	player2.Username = m.Player2.Username
 	player2.Color = m.Player2.Color
 
+	// The replay shows the hands of both players
+	m.replay.recordState(&server.MatchStateMessage{
+		Header: "state_update",
+		State: server.MatchState{
+			Me:        player1,
+			Opponent:  player2,
+			Spectator: true,
+		},
+	})
+
 	p1state := &server.MatchStateMessage{
 		Header: "state_update",
 		State: server.MatchState{
@@ -580,6 +705,7 @@ func (m *Match) BroadcastState() {
 			HasAddedMana: m.Player1.Player.HasChargedMana,
 			Me:           player1,
 			Opponent:     player2,
+			Clock:        m.clockFor(1),
 		},
 	}
 
@@ -590,6 +716,7 @@ func (m *Match) BroadcastState() {
 			HasAddedMana: m.Player2.Player.HasChargedMana,
 			Me:           player2,
 			Opponent:     player1,
+			Clock:        m.clockFor(2),
 		},
 	}
 
@@ -601,6 +728,7 @@ func (m *Match) BroadcastState() {
 			Me:           player1,
 			Opponent:     player2,
 			Spectator:    true,
+			Clock:        m.clockFor(1),
 		},
 	}
 
@@ -609,8 +737,8 @@ func (m *Match) BroadcastState() {
 	spectatorState.State.Me.Hand = make([]server.CardState, 0)
 	spectatorState.State.Opponent.Hand = make([]server.CardState, 0)
 
-	m.Player1.Socket.Send(p1state)
-	m.Player2.Socket.Send(p2state)
+	m.sendState(m.Player1, p1state)
+	m.sendState(m.Player2, p2state)
 
 	m.spectators.RLock()
 	defer m.spectators.RUnlock()
@@ -624,10 +752,43 @@ func (m *Match) BroadcastState() {
 
 }
 
+// sendSpectators sends a message to the spectators of the match
+func (m *Match) sendSpectators(msg interface{}) {
+
+	m.spectators.RLock()
+	defer m.spectators.RUnlock()
+
+	for _, spectator := range m.spectators.users {
+		if spectator.Socket == nil {
+			continue
+		}
+		spectator.Socket.Send(msg)
+	}
+
+}
+
+// send sends a message to the player, unless the player is disconnected
+func (m *Match) send(p *Player, msg interface{}) {
+
+	ref := m.PlayerRef(p)
+
+	if ref.Controller == nil {
+		return
+	}
+
+	ref.Controller.Send(msg)
+
+}
+
 // Warn sends a warning to the specified player ref
 func Warn(p *PlayerReference, message string) {
 
-	p.Socket.Send(server.WarningMessage{
+	// the controller is nil while the player is reconnecting
+	if p.Controller == nil {
+		return
+	}
+
+	p.Controller.Send(server.WarningMessage{
 		Header:  "warn",
 		Message: message,
 	})
@@ -637,7 +798,11 @@ func Warn(p *PlayerReference, message string) {
 // WarnError sends an error message to the specified player ref
 func WarnError(p *PlayerReference, message string) {
 
-	p.Socket.Send(server.WarningMessage{
+	if p.Controller == nil {
+		return
+	}
+
+	p.Controller.Send(server.WarningMessage{
 		Header:  "error",
 		Message: message,
 	})
@@ -646,17 +811,15 @@ func WarnError(p *PlayerReference, message string) {
 
 // WarnPlayer sends a warning to the specified player
 func (m *Match) WarnPlayer(p *Player, message string) {
-
-	m.PlayerRef(p).Socket.Send(server.WarningMessage{
+	m.send(p, server.WarningMessage{
 		Header:  "warn",
 		Message: message,
 	})
-
 }
 
 // ActionWarning adds an error message to the players current action popup
 func (m *Match) ActionWarning(p *Player, message string) {
-	m.PlayerRef(p).Socket.Send(server.ActionWarningMessage{
+	m.send(p, server.ActionWarningMessage{
 		Header:  "action_error",
 		Message: message,
 	})
@@ -664,7 +827,7 @@ func (m *Match) ActionWarning(p *Player, message string) {
 
 // DefaultActionWarning sends an actionw arning with a predefined message
 func (m *Match) DefaultActionWarning(p *Player) {
-	m.PlayerRef(p).Socket.Send(server.ActionWarningMessage{
+	m.send(p, server.ActionWarningMessage{
 		Header:  "action_error",
 		Message: "Your selection of cards does not fulfill the requirements",
 	})
@@ -673,6 +836,9 @@ func (m *Match) DefaultActionWarning(p *Player) {
 // HandleFx ...
 func (m *Match) HandleFx(ctx *Context) {
 
+	m.fxStarted()
+	defer m.fxFinished()
+
 	players := make([]*PlayerReference, 0)
 
 	// The player in which turn it is is to be handled first
@@ -699,8 +865,10 @@ func (m *Match) HandleFx(ctx *Context) {
 
 	// Handle persistent effects
 	for _, card := range cards {
-		for _, fx := range m.persistentEffects {
-			fx.effect(card, ctx, fx.exit)
+		for _, id := range m.persistentEffectIDs() {
+			if fx, ok := m.persistentEffects[id]; ok {
+				fx.effect(card, ctx, fx.exit)
+			}
 		}
 	}
 
@@ -710,6 +878,7 @@ func (m *Match) HandleFx(ctx *Context) {
 		for _, h := range card.handlers {
 
 			if ctx.cancel {
+				ctx.resolveTriggers()
 				return
 			}
 
@@ -719,14 +888,15 @@ func (m *Match) HandleFx(ctx *Context) {
 
 	}
 
-	// Handle ctx.ScheduleAfter effects
-	for _, h := range ctx.postFxs {
+	// Handle ctx.ScheduleAfter effects, including the ones scheduled while doing so
+	for ctx.post = 0; ctx.post < len(ctx.postFxs); ctx.post++ {
 
 		if ctx.cancel {
+			ctx.resolveTriggers()
 			return
 		}
 
-		h()
+		ctx.postFxs[ctx.post]()
 
 	}
 
@@ -744,7 +914,36 @@ func (m *Match) NewAction(player *Player, cards []*Card, minSelections int, maxS
 		Cancellable:   cancellable,
 	}
 
-	m.PlayerRef(player).Socket.Send(msg)
+	m.setPrompt(player, msg, cardIDs(cards), minSelections, maxSelections, cancellable)
+
+	m.send(player, msg)
+	m.updateBot(player)
+
+}
+
+// NewPaymentAction prompts the user to select the mana to play the card with, a legal
+// payment is suggested and selected beforehand if the player can afford the card
+func (m *Match) NewPaymentAction(player *Player, card *Card, mana []*Card, cancellable bool) {
+
+	cost := m.GetManaCost(card)
+
+	msg := &server.ActionMessage{
+		Header:        "action",
+		Cards:         denormalizeCards(mana, false),
+		Text:          fmt.Sprintf("Select %v cards from your manazone to play %v. You must select at least 1 %v civilization card.", cost, card.Name, strings.Join(card.ManaRequirement, " and 1 ")),
+		MinSelections: cost,
+		MaxSelections: cost,
+		Cancellable:   cancellable,
+	}
+
+	if suggested, err := player.SuggestPayment(card); err == nil {
+		msg.Suggested = cardIDs(suggested)
+	}
+
+	m.setPrompt(player, msg, cardIDs(mana), cost, cost, cancellable)
+
+	m.send(player, msg)
+	m.updateBot(player)
 
 }
 
@@ -760,7 +959,10 @@ func (m *Match) NewBacksideAction(player *Player, cards []*Card, minSelections i
 		Cancellable:   cancellable,
 	}
 
-	m.PlayerRef(player).Socket.Send(msg)
+	m.setPrompt(player, msg, cardIDs(cards), minSelections, maxSelections, cancellable)
+
+	m.send(player, msg)
+	m.updateBot(player)
 
 }
 
@@ -782,20 +984,25 @@ func (m *Match) NewMultipartAction(player *Player, cards map[string][]*Card, min
 		Cancellable:   cancellable,
 	}
 
-	m.PlayerRef(player).Socket.Send(msg)
+	m.setPrompt(player, msg, multipartCardIDs(cards), minSelections, maxSelections, cancellable)
+
+	m.send(player, msg)
+	m.updateBot(player)
 
 }
 
 // CloseAction closes the card selection popup for the given player
 func (m *Match) CloseAction(p *Player) {
-	m.PlayerRef(p).Socket.Send(server.Message{
+	m.clearPrompt(p)
+	m.send(p, server.Message{
 		Header: "close_action",
 	})
 }
 
 // Wait sends a waiting popup with a message to the specified player
 func (m *Match) Wait(p *Player, message string) {
-	m.PlayerRef(p).Socket.Send(server.WaitMessage{
+	m.setWait(p, message)
+	m.send(p, server.WaitMessage{
 		Header:  "wait",
 		Message: message,
 	})
@@ -803,14 +1010,15 @@ func (m *Match) Wait(p *Player, message string) {
 
 // EndWait closes the waiting popup for the specified player
 func (m *Match) EndWait(p *Player) {
-	m.PlayerRef(p).Socket.Send(server.Message{
+	m.setWait(p, "")
+	m.send(p, server.Message{
 		Header: "end_wait",
 	})
 }
 
 // ShowCards shows the specified cards to the player with a message of why it is being shown
 func (m *Match) ShowCards(p *Player, message string, cards []string) {
-	m.PlayerRef(p).Socket.Send(server.ShowCardsMessage{
+	m.send(p, server.ShowCardsMessage{
 		Header:  "show_cards",
 		Message: message,
 		Cards:   cards,
@@ -821,8 +1029,15 @@ func (m *Match) ShowCards(p *Player, message string, cards []string) {
 func (m *Match) Start() {
 
 	m.Started = true
+	m.started = time.Now().Unix()
+	// the flag is read by the metrics, outside of the loop
+	atomic.StoreInt32(&m.startedFlag, 1)
 
-	UpdateMatchList()
+	logrus.Infof("Starting match %s with seed %v", m.ID, m.seed)
+
+	if !m.headless {
+		UpdateMatchList()
+	}
 
 	m.Player1.Player.ShuffleDeck()
 	m.Player2.Player.ShuffleDeck()
@@ -835,27 +1050,58 @@ func (m *Match) Start() {
 
 	// match.turn is initialized as 1, so we only need to change it to 2
 	// The opposite of what's defined here will start because BeginNewTurn() changes it
-	if rand.Intn(100) >= 50 {
+	switch m.first {
+	case "":
+		if m.rng.Intn(100) >= 50 {
+			m.Turn = 2
+		}
+	case m.Player1.UID:
 		m.Turn = 2
 	}
 
+	m.first = m.Player2.UID
+
+	if m.Turn == 2 {
+		m.first = m.Player1.UID
+	}
+
 	m.Chat("Server", "The duel has begun!")
 
+	if m.series != nil {
+		m.Chat("Server", m.seriesScore())
+		m.broadcastSince(rematchVersion, m.seriesMessage())
+	}
+
 	m.BeginNewTurn()
 
 }
 
+// SetFirstPlayer makes the player with the uid take the first turn instead of a coin flip
+// deciding it, it has to be called before the match is started
+func (m *Match) SetFirstPlayer(uid string) {
+	m.first = uid
+}
+
+// FirstPlayer returns the uid of the player who took the first turn
+func (m *Match) FirstPlayer() string {
+	return m.first
+}
+
 // BeginNewTurn starts a new turn
 func (m *Match) BeginNewTurn() {
 
 	m.Step = &BeginTurnStep{}
 
+	m.turns++
+
 	if m.Turn == 1 {
 		m.Turn = 2
 	} else {
 		m.Turn = 1
 	}
 
+	m.clockNewTurn()
+
 	ctx := NewContext(m, m.Step)
 
 	m.HandleFx(ctx)
@@ -897,7 +1143,7 @@ func (m *Match) StartOfTurnStep() {
 
 	m.HandleFx(ctx)
 
-	m.Chat("Server", fmt.Sprintf("Your turn, %s", m.CurrentPlayer().Socket.User.Username))
+	m.Chat("Server", fmt.Sprintf("Your turn, %s", m.CurrentPlayer().Username))
 
 	m.DrawStep()
 
@@ -931,6 +1177,11 @@ func (m *Match) ChargeStep() {
 
 	m.HandleFx(ctx)
 
+	// Players controlled in-process take their turn on their own
+	if c, ok := m.CurrentPlayer().Controller.(LocalController); ok {
+		go m.takeTurn(c, m.turns)
+	}
+
 }
 
 // EndStep ...
@@ -942,7 +1193,7 @@ func (m *Match) EndStep() {
 
 	m.HandleFx(ctx)
 
-	m.Chat("Server", fmt.Sprintf("%s ended their turn", m.CurrentPlayer().Socket.User.Username))
+	m.Chat("Server", fmt.Sprintf("%s ended their turn", m.CurrentPlayer().Username))
 
 	m.EndOfTurnTriggers()
 
@@ -999,7 +1250,7 @@ func (m *Match) ChargeMana(p *PlayerReference, cardID string) {
 	if card, err := p.Player.MoveCard(cardID, HAND, MANAZONE); err == nil {
 		p.Player.HasChargedMana = true
 		m.BroadcastState()
-		m.Chat("Server", fmt.Sprintf("%s was added to %s's manazone", card.Name, p.Socket.User.Username))
+		m.Chat("Server", fmt.Sprintf("%s was added to %s's manazone", card.Name, p.Username))
 	}
 
 }
@@ -1007,6 +1258,8 @@ func (m *Match) ChargeMana(p *PlayerReference, cardID string) {
 // PlayCard is called when the player attempts to play a card
 func (m *Match) PlayCard(p *PlayerReference, cardID string) {
 
+	card, _ := p.Player.GetCard(cardID, HAND)
+
 	ctx := NewContext(m, &PlayCardEvent{
 		CardID: cardID,
 	})
@@ -1019,6 +1272,11 @@ func (m *Match) PlayCard(p *PlayerReference, cardID string) {
 		}
 
 		p.Player.CanChargeMana = false
+
+		// the card was played if it left the hand
+		if card != nil && card.Zone != HAND && !m.headless {
+			cardPlays.Inc(card.Name)
+		}
 	}
 
 	m.BroadcastState()
@@ -1084,32 +1342,52 @@ func (m *Match) AttackCreature(p *PlayerReference, cardID string) {
 
 }
 
-// Parse handles websocket messages in this Hub
-func (m *Match) Parse(s *server.Socket, data []byte) {
+// Parse handles a message from a socket in the loop of the match
+func (m *Match) Parse(s *server.Socket, r *server.Request) {
+	m.post(event{fn: func() {
+
+		if commands[r.Header] && (m.ending || !m.allowCommand(s)) {
+			return
+		}
+
+		m.parse(s, r)
+
+	}})
+}
+
+func (m *Match) parse(s *server.Socket, r *server.Request) {
 
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Warnf("Recovered after parsing message in match. %v", r)
+			metrics.Panics.Inc("match_parse")
 		}
 	}()
 
-	var message server.Message
-	if err := json.Unmarshal(data, &message); err != nil {
-		return
+	if m.Started && replayedMessages[r.Header] {
+		if p, err := m.PlayerForSocket(s); err == nil {
+			m.replay.recordMessage(p.UID, r.Header, r.Data)
+		}
 	}
 
-	switch message.Header {
+	switch r.Header {
 
 	case "mpong":
 		{
 
 			p, err := m.PlayerForSocket(s)
 
-			if err != nil {
+			if err == nil {
+				p.LastPong = time.Now().Unix()
 				return
 			}
 
-			p.LastPong = time.Now().Unix()
+			m.spectators.Lock()
+			if spectator, ok := m.spectators.users[s.User.UID]; ok && spectator.Socket == s {
+				spectator.LastPong = time.Now().Unix()
+				m.spectators.users[s.User.UID] = spectator
+			}
+			m.spectators.Unlock()
 
 		}
 
@@ -1121,41 +1399,13 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				// p1 attempting to reconnect
 				if m.Player1 != nil && m.Player1.UID == s.User.UID {
 
-					if m.Player1.Socket != nil {
-						m.Player1.Socket.Close()
-					}
-
-					m.Player1.Socket = s
-
-					if m.Player2 != nil && m.Player2.Socket != nil {
-						m.Player2.Socket.Send(server.Message{
-							Header: "opponent_reconnected",
-						})
-					}
-
-					m.BroadcastState()
-					m.Chat("Server", s.User.Username+" reconnected")
-
+					m.Reconnect(m.Player1, s)
 					return
 
 					// p2 attempting to reconnect
 				} else if m.Player2 != nil && m.Player2.UID == s.User.UID {
 
-					if m.Player2.Socket != nil {
-						m.Player2.Socket.Close()
-					}
-
-					m.Player2.Socket = s
-
-					if m.Player1 != nil && m.Player1.Socket != nil {
-						m.Player1.Socket.Send(server.Message{
-							Header: "opponent_reconnected",
-						})
-					}
-
-					m.BroadcastState()
-					m.Chat("Server", s.User.Username+" reconnected")
-
+					m.Reconnect(m.Player2, s)
 					return
 
 				} else {
@@ -1187,6 +1437,7 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 
 					m.Chat("Server", fmt.Sprintf("%s started spectating", s.User.Username))
 					m.BroadcastState()
+					m.resendChoosing(s)
 					return
 				}
 			}
@@ -1207,12 +1458,23 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				p := NewPlayer(m, 1)
 
 				m.Player1 = NewPlayerReference(p, s)
+				m.seat(s.User.UID)
 
 			}
 
 			// This is player2
 			if s.User.UID != m.HostID {
 
+				// Matches created by the matchmaking queue can only be joined by the paired user
+				if m.GuestID != "" && s.User.UID != m.GuestID {
+					s.Send(server.WarningMessage{
+						Header:  "error",
+						Message: "This match is reserved for another player",
+					})
+					s.Close()
+					return
+				}
+
 				if m.Player2 != nil {
 					logrus.Debug("Attempt to join as Player2 multiple times")
 					s.Send(server.WarningMessage{
@@ -1226,58 +1488,37 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				p := NewPlayer(m, 2)
 
 				m.Player2 = NewPlayerReference(p, s)
+				m.seat(s.User.UID)
 
 			}
 
 			// If both players have joined, prompt them to choose their decks
 			if m.Player1 != nil && m.Player2 != nil {
 
-				collection := db.Collection("decks")
-
-				cur, err := collection.Find(context.TODO(), bson.M{
-					"$or": []bson.M{
-						{"owner": m.Player1.Socket.User.UID},
-						{"owner": m.Player2.Socket.User.UID},
-						{"standard": true},
-					},
-				})
+				player1decks, err := db.Decks().GetAvailable(m.Player1.UID)
 
 				if err != nil {
 					logrus.Error(err)
 					return
 				}
 
-				defer cur.Close(context.TODO())
-
-				player1decks := make([]db.Deck, 0)
-				player2decks := make([]db.Deck, 0)
-
-				for cur.Next(context.TODO()) {
-
-					var deck db.Deck
-
-					if err := cur.Decode(&deck); err != nil {
-						continue
-					}
-
-					if deck.Owner == m.Player1.Socket.User.UID || deck.Standard {
-						player1decks = append(player1decks, deck)
-					}
-
-					if deck.Owner == m.Player2.Socket.User.UID || deck.Standard {
-						player2decks = append(player2decks, deck)
-					}
+				player2decks, err := db.Decks().GetAvailable(m.Player2.UID)
 
+				if err != nil {
+					logrus.Error(err)
+					return
 				}
 
-				m.Player1.Socket.Send(server.DecksMessage{
-					Header: "choose_deck",
-					Decks:  player1decks,
+				m.send(m.Player1.Player, server.DecksMessage{
+					Header:   "choose_deck",
+					Decks:    player1decks,
+					Previous: m.previous[m.Player1.UID],
 				})
 
-				m.Player2.Socket.Send(server.DecksMessage{
-					Header: "choose_deck",
-					Decks:  player2decks,
+				m.send(m.Player2.Player, server.DecksMessage{
+					Header:   "choose_deck",
+					Decks:    player2decks,
+					Previous: m.previous[m.Player2.UID],
 				})
 
 				m.Chat("Server", "Waiting for both players to choose a deck")
@@ -1293,16 +1534,10 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 
 			// Allow other sockets than player1 and player2 to chat?
 
-			var msg struct {
-				Message string `json:"message"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
+			msg := r.Message.(*server.ChatRequest)
 
 			runes := []rune(msg.Message)
-			if string(runes[0:4]) == "/add" {
+			if len(runes) > 4 && string(runes[0:4]) == "/add" {
 
 				hasRights := false
 
@@ -1335,28 +1570,23 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			var msg struct {
-				UID string `json:"uid"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
+			msg := r.Message.(*server.ChooseDeckRequest)
 
-			var deck db.Deck
+			deck, err := db.Decks().Get(msg.UID)
 
-			if err := db.Collection("decks").FindOne(context.TODO(), bson.M{"uid": msg.UID}).Decode(&deck); err != nil {
+			if err != nil {
+				s.Send(server.WarningMessage{
+					Header:  "deck_rejected",
+					Message: "The deck could not be found",
+				})
 				return
 			}
 
-			p.Player.CreateDeck(deck.Cards)
-
-			m.Chat("Server", fmt.Sprintf("%s has chosen their deck", s.User.Username))
-
-			p.Player.Ready = true
-
-			if m.Player1.Player.Ready && m.Player2.Player.Ready {
-				m.Start()
+			if err := m.ChooseDeck(p, deck); err != nil {
+				s.Send(server.WarningMessage{
+					Header:  "deck_rejected",
+					Message: err.Error(),
+				})
 			}
 
 		}
@@ -1374,15 +1604,44 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			var msg struct {
-				ID string `json:"virtualId"`
+			msg := r.Message.(*server.CardRequest)
+
+			m.ChargeMana(p, msg.ID)
+
+		}
+
+	case "choose_first":
+		{
+
+			p, err := m.PlayerForSocket(s)
+
+			if err != nil {
+				return
 			}
 
-			if err := json.Unmarshal(data, &msg); err != nil {
+			m.ChooseFirst(p, r.Message.(*server.ChooseFirstRequest).First)
+
+		}
+
+	case "concede", "offer_draw", "accept_draw", "rematch":
+		{
+
+			p, err := m.PlayerForSocket(s)
+
+			if err != nil {
 				return
 			}
 
-			m.ChargeMana(p, msg.ID)
+			switch r.Header {
+			case "concede":
+				m.Concede(p)
+			case "offer_draw":
+				m.OfferDraw(p)
+			case "accept_draw":
+				m.AcceptDraw(p)
+			case "rematch":
+				m.Rematch(p)
+			}
 
 		}
 
@@ -1416,13 +1675,7 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			var msg struct {
-				ID string `json:"virtualId"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
+			msg := r.Message.(*server.CardRequest)
 
 			m.PlayCard(p, msg.ID)
 
@@ -1437,28 +1690,12 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			msg := PlayerAction{}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				m.ActionWarning(p.Player, "Invalid selection")
-				return
-			}
-
-			// Check to see if the client is trying something fishy with selecting the same card multiple times
-			for _, c := range msg.Cards {
-				count := 0
-				for _, c2 := range msg.Cards {
-					if c == c2 {
-						count++
-					}
-				}
-				if count >= 2 {
-					m.ActionWarning(p.Player, "You cannot select the same card multiple times")
-					return
-				}
-			}
+			msg := r.Message.(*server.ActionRequest)
 
-			p.Player.Action <- msg
+			m.respond(p.Player, PlayerAction{
+				Cards:  msg.Cards,
+				Cancel: msg.Cancel,
+			})
 
 		}
 
@@ -1475,13 +1712,7 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			var msg struct {
-				ID string `json:"virtualId"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
+			msg := r.Message.(*server.CardRequest)
 
 			m.AttackPlayer(p, msg.ID)
 
@@ -1500,47 +1731,68 @@ func (m *Match) Parse(s *server.Socket, data []byte) {
 				return
 			}
 
-			var msg struct {
-				ID string `json:"virtualId"`
-			}
-
-			if err := json.Unmarshal(data, &msg); err != nil {
-				return
-			}
+			msg := r.Message.(*server.CardRequest)
 
 			m.AttackCreature(p, msg.ID)
 
 		}
 
-	default:
-		{
-			logrus.Debugf("Received message in incorrect format: %v", string(data))
-		}
+	}
+
+}
 
+// ChooseDeck creates the deck of the player, the match is started once both players have chosen their deck.
+// An error is returned if the deck is not legal
+func (m *Match) ChooseDeck(p *PlayerReference, deck db.Deck) error {
+
+	if err := format.Standard.Validate(deck.Cards); err != nil {
+		return err
+	}
+
+	p.Player.CreateDeck(deck.Cards)
+	p.Deck = deck.UID
+
+	m.replay.addPlayer(p, deck.Cards)
+
+	m.Chat("Server", fmt.Sprintf("%s has chosen their deck", p.Username))
+
+	p.Player.Ready = true
+
+	if m.Player1.Player.Ready && m.Player2.Player.Ready {
+		m.Start()
 	}
 
+	return nil
+
 }
 
-// OnSocketClose is called when a socket disconnects
+// OnSocketClose is called when a socket disconnects. Sockets are also closed by the
+// match itself, so the disconnect is handled in the loop without waiting for it
 func (m *Match) OnSocketClose(s *server.Socket) {
+	go m.post(event{fn: func() { m.socketClosed(s) }})
+}
+
+func (m *Match) socketClosed(s *server.Socket) {
 
 	if m.closed {
 		return
 	}
 
 	if !m.Started {
-		m.quit <- true
+		m.stop()
 		return
 	}
 
 	// is this a spectator leaving?
 	m.spectators.Lock()
-	defer m.spectators.Unlock()
 	spectator, ok := m.spectators.users[s.User.UID]
+	if ok {
+		delete(m.spectators.users, spectator.UID)
+	}
+	m.spectators.Unlock()
 
 	if ok {
 		m.Chat("Server", fmt.Sprintf("%s stopped spectating", spectator.Username))
-		delete(m.spectators.users, spectator.UID)
 		return
 	}
 
@@ -1548,17 +1800,17 @@ func (m *Match) OnSocketClose(s *server.Socket) {
 	var o *PlayerReference
 
 	// assign the above variables, player and opponent of the closing socket
-	if m.Player1 != nil && m.Player1.Socket == s {
+	if m.Player1 != nil && m.Player1.Controller == s {
 		p = m.Player1
 
-		if m.Player2 != nil && m.Player2.Socket != nil {
+		if m.Player2 != nil && m.Player2.Controller != nil {
 			o = m.Player2
 		}
 
-	} else if m.Player2 != nil && m.Player2.Socket == s {
+	} else if m.Player2 != nil && m.Player2.Controller == s {
 		p = m.Player2
 
-		if m.Player1 != nil && m.Player1.Socket != nil {
+		if m.Player1 != nil && m.Player1.Controller != nil {
 			o = m.Player1
 		}
 	}
@@ -1567,18 +1819,25 @@ func (m *Match) OnSocketClose(s *server.Socket) {
 		return
 	}
 
-	if o != nil {
+	// there is no rematch without this player
+	if m.ending {
+		m.stop()
+		return
+	}
+
+	if o != nil && o.Controller != nil {
 		// let the opponent know that this player has disconnected
-		o.Socket.Send(server.Message{
+		o.Controller.Send(server.Message{
 			Header: "opponent_disconnected",
 		})
 	}
 
-	p.Socket = nil
+	p.Controller = nil
+	p.disconnected = time.Now()
 
 	// if both players have disconnected, close match
-	if (p == nil || p.Socket == nil) && (o == nil || o.Socket == nil) {
-		m.quit <- true
+	if (p == nil || p.Controller == nil) && (o == nil || o.Controller == nil) {
+		m.stop()
 	}
 
 }
@@ -0,0 +1,69 @@
+package match_test
+
+import (
+	"duel-masters/game/cnd"
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+func TestGetManaCost(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{Hand: []string{phantomFish}, Manazone: []string{phantomFish, phantomFish, phantomFish}},
+		matchtest.Board{},
+	)
+
+	fish := h.P1.Card(match.HAND, phantomFish)
+	mana, _ := h.P1.Player.Container(match.MANAZONE)
+
+	if cost := h.Match.GetManaCost(fish); cost != 3 {
+		t.Errorf("Expected the printed cost of 3, got %v", cost)
+	}
+
+	fish.AddCondition(cnd.IncreasedCost, 1, nil)
+
+	if cost := h.Match.GetManaCost(fish); cost != 4 {
+		t.Errorf("Expected an increased cost of 4, got %v", cost)
+	}
+
+	if h.P1.Player.CanPlayCard(fish, mana) {
+		t.Error("Expected the card not to be playable with 3 mana when its cost is increased")
+	}
+
+	fish.AddCondition(cnd.ReducedCost, 5, nil)
+
+	if cost := h.Match.GetManaCost(fish); cost != 1 {
+		t.Errorf("Expected the cost to be at least 1, got %v", cost)
+	}
+
+}
+
+func TestBroadcastStateOnlyCostsHands(t *testing.T) {
+
+	h := matchtest.New(t,
+		matchtest.Board{
+			Hand:       []string{phantomFish, phantomFish},
+			Manazone:   []string{phantomFish, phantomFish, phantomFish},
+			Graveyard:  []string{phantomFish},
+			Battlezone: []string{phantomFish, phantomFish},
+		},
+		matchtest.Board{Hand: []string{phantomFish}, Battlezone: []string{aquaHulcus}},
+	)
+
+	costs := 0
+
+	h.P2.Card(match.BATTLEZONE, aquaHulcus).Use(func(card *match.Card, ctx *match.Context) {
+		if _, ok := ctx.Event.(*match.GetManaCostEvent); ok {
+			costs++
+		}
+	})
+
+	h.Do(func() { h.Match.BroadcastState() })
+
+	// The cost is only needed to tell whether the cards in the hands can be played
+	if costs != 3 {
+		t.Errorf("Expected the mana cost of the 3 cards in the hands to be calculated, got %v calculations", costs)
+	}
+
+}
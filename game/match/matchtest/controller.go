@@ -0,0 +1,162 @@
+package matchtest
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"sync"
+)
+
+// Prompt is an action prompt that was sent to a player
+type Prompt struct {
+	Text        string
+	Cards       []server.CardState
+	Min         int
+	Max         int
+	Cancellable bool
+}
+
+// Response returns the selection for a prompt
+type Response func(prompt Prompt) match.PlayerAction
+
+// Select selects the first card in the prompt for each of the given card uids
+func Select(uids ...string) Response {
+
+	return func(prompt Prompt) match.PlayerAction {
+
+		selected := make(map[string]bool)
+		result := make([]string, 0)
+
+		for _, uid := range uids {
+			for _, c := range prompt.Cards {
+				if c.ImageID == uid && !selected[c.CardID] {
+					selected[c.CardID] = true
+					result = append(result, c.CardID)
+					break
+				}
+			}
+		}
+
+		return match.PlayerAction{Cards: result}
+
+	}
+
+}
+
+// SelectIDs selects the cards with the given ids
+func SelectIDs(ids ...string) Response {
+
+	return func(prompt Prompt) match.PlayerAction {
+		return match.PlayerAction{Cards: ids}
+	}
+
+}
+
+// SelectFirst selects the first n cards of the prompt, used for prompts where
+// the cards are hidden such as when selecting shields to break
+func SelectFirst(n int) Response {
+
+	return func(prompt Prompt) match.PlayerAction {
+
+		result := make([]string, 0)
+
+		for i := 0; i < n && i < len(prompt.Cards); i++ {
+			result = append(result, prompt.Cards[i].CardID)
+		}
+
+		return match.PlayerAction{Cards: result}
+
+	}
+
+}
+
+// Cancel closes the prompt without making a selection
+func Cancel() Response {
+
+	return func(prompt Prompt) match.PlayerAction {
+		return match.PlayerAction{Cancel: true}
+	}
+
+}
+
+// controller records every message sent to a player and answers prompts
+// with the queued responses
+type controller struct {
+	h         *Harness
+	player    *match.PlayerReference
+	messages  []interface{}
+	responses []Response
+	prompt    *Prompt
+	mutex     sync.Mutex
+}
+
+// Send records the message and responds to action prompts
+func (c *controller) Send(msg interface{}) {
+
+	c.mutex.Lock()
+	c.messages = append(c.messages, msg)
+	c.mutex.Unlock()
+
+	switch msg := msg.(type) {
+
+	case *server.ActionMessage:
+		c.respond(&Prompt{
+			Text:        msg.Text,
+			Cards:       msg.Cards,
+			Min:         msg.MinSelections,
+			Max:         msg.MaxSelections,
+			Cancellable: msg.Cancellable,
+		})
+
+	case *server.MultipartActionMessage:
+		cards := make([]server.CardState, 0)
+		for _, group := range msg.Cards {
+			cards = append(cards, group...)
+		}
+
+		c.respond(&Prompt{
+			Text:        msg.Text,
+			Cards:       cards,
+			Min:         msg.MinSelections,
+			Max:         msg.MaxSelections,
+			Cancellable: msg.Cancellable,
+		})
+
+	case server.ActionWarningMessage:
+		// The match is still waiting for a valid response to the same prompt
+		c.h.T.Errorf("%s's response was rejected: %s", c.player.Username, msg.Message)
+		if c.prompt != nil {
+			c.respond(c.prompt)
+		}
+
+	case server.Message:
+		if msg.Header == "close_action" {
+			c.prompt = nil
+		}
+
+	}
+
+}
+
+func (c *controller) respond(prompt *Prompt) {
+
+	c.prompt = prompt
+
+	action := match.PlayerAction{Cancel: true}
+
+	c.mutex.Lock()
+	if len(c.responses) > 0 {
+		action = c.responses[0](*prompt)
+		c.responses = c.responses[1:]
+	} else {
+		c.h.T.Errorf("%s received an unexpected prompt: %s", c.player.Username, prompt.Text)
+	}
+	c.mutex.Unlock()
+
+	// Send is called from the loop of the match, which handles the response
+	// once it is done sending the prompt
+	go c.player.Player.Respond(action)
+
+}
+
+// Close is called when the match is disposed
+func (c *controller) Close() {}
@@ -0,0 +1,370 @@
+// Package matchtest provides a harness for testing card behaviour in a headless match.
+// Board states are set up declaratively, prompts are answered with scripted responses
+// and the resulting zones, card states and chat messages can be asserted on
+package matchtest
+
+import (
+	"duel-masters/game/cards"
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Timeout is how long a command is allowed to run before the test fails,
+// which usually happens when the match is waiting for a response that was never queued
+var Timeout = 5 * time.Second
+
+var registerOnce sync.Once
+
+// Register adds every card in cards.Sets to the match and format packages
+func Register() {
+
+	registerOnce.Do(func() {
+		cards.RegisterAll()
+	})
+
+}
+
+// Board holds the card uids to put into each of a player's zones
+type Board struct {
+	Deck       []string
+	Hand       []string
+	Shieldzone []string
+	Manazone   []string
+	Graveyard  []string
+	Battlezone []string
+}
+
+// Harness is a headless match between two test players
+type Harness struct {
+	T     testing.TB
+	Match *match.Match
+	P1    *Player
+	P2    *Player
+}
+
+// Player is a test player in the harness
+type Player struct {
+	*match.PlayerReference
+	h          *Harness
+	controller *controller
+}
+
+// New returns a harness with a started match where the players have the given boards.
+// It is player1's first turn when New returns. Cards are put directly into their zones
+// without firing any events, so creatures in the battlezone can attack right away
+func New(t testing.TB, board1 Board, board2 Board) *Harness {
+
+	Register()
+
+	h := &Harness{
+		T:     t,
+		Match: match.NewHeadless("matchtest"),
+	}
+
+	h.P1 = h.addPlayer("p1", "player1", board1)
+	h.P2 = h.addPlayer("p2", "player2", board2)
+
+	t.Cleanup(h.Match.Dispose)
+
+	h.Match.Started = true
+
+	// BeginNewTurn passes the turn, start from player2 to begin with player1
+	h.Match.Turn = 2
+	h.Do(h.Match.BeginNewTurn)
+
+	return h
+
+}
+
+func (h *Harness) addPlayer(uid string, username string, board Board) *Player {
+
+	c := &controller{h: h, messages: make([]interface{}, 0)}
+
+	ref, err := h.Match.AddPlayer(uid, username, c)
+
+	if err != nil {
+		h.T.Fatal(err)
+	}
+
+	c.player = ref
+
+	zones := map[string][]string{
+		match.DECK:       board.Deck,
+		match.HAND:       board.Hand,
+		match.SHIELDZONE: board.Shieldzone,
+		match.MANAZONE:   board.Manazone,
+		match.GRAVEYARD:  board.Graveyard,
+		match.BATTLEZONE: board.Battlezone,
+	}
+
+	for zone, uids := range zones {
+		for _, id := range uids {
+			if _, err := ref.Player.PutCard(id, zone); err != nil {
+				h.T.Fatalf("Could not put %s into %s: %v", id, zone, err)
+			}
+		}
+	}
+
+	return &Player{
+		PlayerReference: ref,
+		h:               h,
+		controller:      c,
+	}
+
+}
+
+// Do calls f in the loop of the match and fails the test if it panics or does not
+// return within the timeout
+func (h *Harness) Do(f func()) {
+
+	h.T.Helper()
+
+	done := make(chan interface{}, 1)
+
+	go h.Match.Do(func() {
+
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Sprintf("%v\n%s", r, debug.Stack())
+			}
+		}()
+
+		f()
+
+		done <- nil
+
+	})
+
+	select {
+	case r := <-done:
+		if r != nil {
+			h.T.Fatalf("Panic during command: %v", r)
+		}
+	case <-time.After(Timeout):
+		h.T.Fatalf("Command did not finish within %v, the match is probably waiting for a response", Timeout)
+	}
+
+}
+
+// EndTurn ends the current player's turn and begins the next
+func (h *Harness) EndTurn() {
+	h.T.Helper()
+	h.Do(h.Match.EndTurn)
+}
+
+// Chat returns all chat messages sent during the match
+func (h *Harness) Chat() []string {
+
+	result := make([]string, 0)
+
+	for _, msg := range h.P1.Messages() {
+		if chat, ok := msg.(*server.ChatMessage); ok {
+			result = append(result, chat.Message)
+		}
+	}
+
+	return result
+
+}
+
+// AssertChat fails the test if no chat message contains the given text
+func (h *Harness) AssertChat(text string) {
+
+	h.T.Helper()
+
+	for _, msg := range h.Chat() {
+		if strings.Contains(msg, text) {
+			return
+		}
+	}
+
+	h.T.Errorf("Expected a chat message containing %q", text)
+
+}
+
+// Respond queues responses for the next prompts sent to the player, in order
+func (p *Player) Respond(responses ...Response) {
+
+	p.controller.mutex.Lock()
+	defer p.controller.mutex.Unlock()
+
+	p.controller.responses = append(p.controller.responses, responses...)
+
+}
+
+// Messages returns a copy of all messages sent to the player
+func (p *Player) Messages() []interface{} {
+
+	p.controller.mutex.Lock()
+	defer p.controller.mutex.Unlock()
+
+	result := make([]interface{}, len(p.controller.messages))
+	copy(result, p.controller.messages)
+
+	return result
+
+}
+
+// Warnings returns all warnings sent to the player
+func (p *Player) Warnings() []string {
+
+	result := make([]string, 0)
+
+	for _, msg := range p.Messages() {
+		if warning, ok := msg.(server.WarningMessage); ok {
+			result = append(result, warning.Message)
+		}
+	}
+
+	return result
+
+}
+
+// Card returns the first card with the given uid in the zone and fails the test if there is none
+func (p *Player) Card(zone string, uid string) *match.Card {
+
+	p.h.T.Helper()
+
+	cards, err := p.Player.Container(zone)
+
+	if err != nil {
+		p.h.T.Fatal(err)
+	}
+
+	for _, c := range cards {
+		if c.ImageID == uid {
+			return c
+		}
+	}
+
+	p.h.T.Fatalf("%s has no card %s in %s", p.Username, uid, zone)
+
+	return nil
+
+}
+
+// Zone returns the uids of the cards in the zone
+func (p *Player) Zone(zone string) []string {
+
+	p.h.T.Helper()
+
+	cards, err := p.Player.Container(zone)
+
+	if err != nil {
+		p.h.T.Fatal(err)
+	}
+
+	result := make([]string, 0)
+
+	for _, c := range cards {
+		result = append(result, c.ImageID)
+	}
+
+	return result
+
+}
+
+// Charge puts the card with the given uid from the player's hand into their manazone
+func (p *Player) Charge(uid string) {
+	p.h.T.Helper()
+	id := p.Card(match.HAND, uid).ID
+	p.h.Do(func() { p.h.Match.ChargeMana(p.PlayerReference, id) })
+}
+
+// Play plays the card with the given uid from the player's hand
+func (p *Player) Play(uid string) {
+	p.h.T.Helper()
+	id := p.Card(match.HAND, uid).ID
+	p.h.Do(func() { p.h.Match.PlayCard(p.PlayerReference, id) })
+}
+
+// AttackPlayer attacks the opponent with the creature with the given uid
+func (p *Player) AttackPlayer(uid string) {
+	p.h.T.Helper()
+	id := p.Card(match.BATTLEZONE, uid).ID
+	p.h.Do(func() { p.h.Match.AttackPlayer(p.PlayerReference, id) })
+}
+
+// AttackCreature attacks one of the opponent's creatures with the creature with the given uid
+func (p *Player) AttackCreature(uid string) {
+	p.h.T.Helper()
+	id := p.Card(match.BATTLEZONE, uid).ID
+	p.h.Do(func() { p.h.Match.AttackCreature(p.PlayerReference, id) })
+}
+
+// AssertZone fails the test if the zone does not hold exactly the given uids, in any order
+func (p *Player) AssertZone(zone string, uids ...string) {
+
+	p.h.T.Helper()
+
+	actual := p.Zone(zone)
+
+	remaining := make(map[string]int)
+	for _, uid := range actual {
+		remaining[uid]++
+	}
+
+	ok := len(actual) == len(uids)
+
+	for _, uid := range uids {
+		if remaining[uid] < 1 {
+			ok = false
+		}
+		remaining[uid]--
+	}
+
+	if !ok {
+		p.h.T.Errorf("Expected %s's %s to be %v, got %v", p.Username, zone, uids, actual)
+	}
+
+}
+
+// AssertCount fails the test if the zone does not hold n cards
+func (p *Player) AssertCount(zone string, n int) {
+
+	p.h.T.Helper()
+
+	if actual := len(p.Zone(zone)); actual != n {
+		p.h.T.Errorf("Expected %s's %s to have %v cards, got %v", p.Username, zone, n, actual)
+	}
+
+}
+
+// AssertTapped fails the test if the tapped state of the card is not as expected
+func (h *Harness) AssertTapped(card *match.Card, tapped bool) {
+
+	h.T.Helper()
+
+	if card.Tapped != tapped {
+		h.T.Errorf("Expected %s tapped to be %v", card.Name, tapped)
+	}
+
+}
+
+// AssertCondition fails the test if the card does not have the condition
+func (h *Harness) AssertCondition(card *match.Card, condition string) {
+
+	h.T.Helper()
+
+	if !card.HasCondition(condition) {
+		h.T.Errorf("Expected %s to have condition %s", card.Name, condition)
+	}
+
+}
+
+// AssertNoCondition fails the test if the card has the condition
+func (h *Harness) AssertNoCondition(card *match.Card, condition string) {
+
+	h.T.Helper()
+
+	if card.HasCondition(condition) {
+		h.T.Errorf("Expected %s not to have condition %s", card.Name, condition)
+	}
+
+}
@@ -0,0 +1,72 @@
+package match
+
+import (
+	"duel-masters/metrics"
+	"sync/atomic"
+	"time"
+)
+
+// cardPlays counts the cards that were played in matches against users, by card name
+var cardPlays = metrics.NewCounter("duelmasters_card_plays_total", "Cards that were played, by card name", "card")
+
+// matchDuration tracks how long the started matches lasted
+var matchDuration = metrics.NewSummary("duelmasters_match_duration_seconds", "Duration of the matches that were started, from the start until they were closed")
+
+func init() {
+
+	metrics.NewGaugeFunc("duelmasters_matches", "Open matches, by whether they are waiting for players or started", "state", func() map[string]float64 {
+
+		result := map[string]float64{"waiting": 0, "started": 0}
+
+		matchesMutex.Lock()
+		defer matchesMutex.Unlock()
+
+		for _, m := range matches {
+
+			// Started is owned by the loop of the match
+			if atomic.LoadInt32(&m.startedFlag) == 1 {
+				result["started"]++
+				continue
+			}
+
+			result["waiting"]++
+
+		}
+
+		return result
+
+	})
+
+	metrics.NewGaugeFunc("duelmasters_spectators", "Users spectating a match", "", func() map[string]float64 {
+
+		matchesMutex.Lock()
+		open := make([]*Match, 0, len(matches))
+		for _, m := range matches {
+			open = append(open, m)
+		}
+		matchesMutex.Unlock()
+
+		total := 0
+
+		for _, m := range open {
+			m.spectators.RLock()
+			total += len(m.spectators.users)
+			m.spectators.RUnlock()
+		}
+
+		return map[string]float64{"": float64(total)}
+
+	})
+
+}
+
+// observeDuration records the duration of the match once it is closed
+func (m *Match) observeDuration() {
+
+	if m.headless || !m.Started {
+		return
+	}
+
+	matchDuration.Observe(float64(time.Now().Unix() - m.started))
+
+}
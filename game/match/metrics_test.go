@@ -0,0 +1,75 @@
+package match_test
+
+import (
+	"bytes"
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/metrics"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// startedMatches returns the number of started matches reported by the metrics
+func startedMatches(t *testing.T) int {
+
+	var buf bytes.Buffer
+	metrics.Write(&buf)
+
+	prefix := `duelmasters_matches{state="started"} `
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimPrefix(line, prefix))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return n
+
+	}
+
+	t.Fatal("Expected the metrics to contain the started matches")
+
+	return 0
+
+}
+
+// TestMatchMetrics scrapes the metrics while a match is started in its loop
+func TestMatchMetrics(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	before := startedMatches(t)
+
+	m := match.New("metrics", "p1", false)
+	defer m.Dispose()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		startMatch(m)
+	}()
+
+	for i := 0; i < 100; i++ {
+		startedMatches(t)
+	}
+
+	<-done
+
+	if started := startedMatches(t); started != before+1 {
+		t.Errorf("Expected %v started matches, got %v", before+1, started)
+	}
+
+}
@@ -1,5 +1,7 @@
 package match
 
+import "sort"
+
 var seqID = 1
 
 type PersistentHandlerFunc func(card *Card, ctx *Context, exit func())
@@ -13,12 +15,14 @@ func (match *Match) ApplyPersistentEffect(f PersistentHandlerFunc) {
 
 	seqID++
 
+	id := seqID
+
 	fx := PersistentEffect{
-		exit:   func() { match.RemovePersistentEffect(seqID) },
+		exit:   func() { match.RemovePersistentEffect(id) },
 		effect: f,
 	}
 
-	match.persistentEffects[seqID] = fx
+	match.persistentEffects[id] = fx
 
 }
 
@@ -31,3 +35,19 @@ func (match *Match) RemovePersistentEffect(id int) {
 	}
 
 }
+
+// persistentEffectIDs returns the ids of the active persistent effects in the order
+// they were applied, so that they are always handled in the same order
+func (match *Match) persistentEffectIDs() []int {
+
+	ids := make([]int, 0, len(match.persistentEffects))
+
+	for id := range match.persistentEffects {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+
+}
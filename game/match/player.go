@@ -1,11 +1,9 @@
 package match
 
 import (
-	"duel-masters/game/cnd"
 	"duel-masters/server"
 	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
 	"time"
 
@@ -24,14 +22,18 @@ const (
 	HIDDENZONE = "hiddenzone"
 )
 
-// PlayerReference ties a player to a websocket connection
+// PlayerReference ties a player to the controller used to communicate with it,
+// usually a websocket connection
 type PlayerReference struct {
-	UID      string
-	Username string
-	Color    string
-	Player   *Player
-	Socket   *server.Socket
-	LastPong int64
+	UID        string
+	Username   string
+	Color      string
+	Deck       string
+	Player     *Player
+	Controller PlayerController
+	LastPong   int64
+
+	disconnected time.Time
 }
 
 type Spectators struct {
@@ -53,16 +55,16 @@ type PlayerAction struct {
 	Cancel bool     `json:"cancel"`
 }
 
-// NewPlayerReference returns a new player reference
+// NewPlayerReference returns a new player reference for a websocket connection
 func NewPlayerReference(p *Player, s *server.Socket) *PlayerReference {
 
 	pr := &PlayerReference{
-		UID:      s.User.UID,
-		Username: s.User.Username,
-		Color:    s.User.Color,
-		Player:   p,
-		Socket:   s,
-		LastPong: time.Now().Unix(),
+		UID:        s.User.UID,
+		Username:   s.User.Username,
+		Color:      s.User.Color,
+		Player:     p,
+		Controller: s,
+		LastPong:   time.Now().Unix(),
 	}
 
 	return pr
@@ -82,14 +84,16 @@ type Player struct {
 
 	mutex *sync.Mutex
 
-	Action chan PlayerAction
-
 	HasChargedMana bool
 	CanChargeMana  bool
 	Turn           byte
 	Ready          bool
 
-	match *Match
+	match    *Match
+	prompt   *prompt
+	response *PlayerAction
+	wait     string
+	cards    int
 }
 
 // NewPlayer returns a new player
@@ -105,7 +109,6 @@ func NewPlayer(match *Match, turn byte) *Player {
 		spellzone:      make([]*Card, 0),
 		hiddenzone:     make([]*Card, 0),
 		mutex:          &sync.Mutex{},
-		Action:         make(chan PlayerAction),
 		HasChargedMana: false,
 		CanChargeMana:  true,
 		Turn:           turn,
@@ -201,6 +204,7 @@ func (p *Player) CreateDeck(deck []string) {
 		}
 
 		p.deck = append(p.deck, c)
+		p.cards++
 
 	}
 
@@ -224,6 +228,36 @@ func (p *Player) SpawnCard(id string) {
 	c.Zone = HAND
 
 	p.hand = append(p.hand, c)
+	p.cards++
+
+}
+
+// PutCard creates a new card from an id and puts it directly into the specified container
+// without firing any events, used to set up board states in tests
+func (p *Player) PutCard(id string, container string) (*Card, error) {
+
+	c, err := NewCard(p, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := p.ContainerRef(container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+
+	defer p.mutex.Unlock()
+
+	c.Zone = container
+
+	*cards = append(*cards, c)
+	p.cards++
+
+	return c, nil
 
 }
 
@@ -232,7 +266,7 @@ func (p *Player) ShuffleDeck() {
 
 	p.mutex.Lock()
 
-	rand.Shuffle(len(p.deck), func(i, j int) { p.deck[i], p.deck[j] = p.deck[j], p.deck[i] })
+	p.match.rng.Shuffle(len(p.deck), func(i, j int) { p.deck[i], p.deck[j] = p.deck[j], p.deck[i] })
 
 	p.mutex.Unlock()
 
@@ -294,14 +328,14 @@ func (p *Player) DrawCards(n int) {
 	}
 
 	if n > 1 {
-		p.match.Chat("Server", fmt.Sprintf("%s drew %v cards", p.match.PlayerRef(p).Socket.User.Username, n))
+		p.match.Chat("Server", fmt.Sprintf("%s drew %v cards", p.match.PlayerRef(p).Username, n))
 	} else {
-		p.match.Chat("Server", fmt.Sprintf("%s drew %v card", p.match.PlayerRef(p).Socket.User.Username, n))
+		p.match.Chat("Server", fmt.Sprintf("%s drew %v card", p.match.PlayerRef(p).Username, n))
 	}
 
 	if len(p.deck) <= 0 {
 		// deck out
-		p.match.End(p.match.Opponent(p), fmt.Sprintf("%s won by deck out!", p.match.Opponent(p).Username()))
+		p.match.End(p.match.Opponent(p), DeckOut, fmt.Sprintf("%s won by deck out!", p.match.Opponent(p).Username()))
 	}
 
 }
@@ -468,40 +502,26 @@ func (p *Player) CanPlayCard(card *Card, mana []*Card) bool {
 		}
 	}
 
-	manaCost := card.ManaCost
-	for _, condition := range card.Conditions() {
-		if condition.ID == cnd.ReducedCost {
-			if condition.ID == cnd.ReducedCost {
-				manaCost -= condition.Val.(int)
-				if manaCost < 1 {
-					manaCost = 1
-				}
-			}
-
-			if condition.ID == cnd.IncreasedCost {
-				manaCost += condition.Val.(int)
-			}
-		}
-	}
+	manaCost := p.match.GetManaCost(card)
 
 	if manaCost > len(untappedMana) {
 		return false
 	}
 
-	for _, manaCard := range untappedMana {
-		for _, civ := range card.ManaRequirement {
-			if manaCard.Civ == civ {
-				return true
-			}
-		}
-	}
+	_, ok := payCivs(card.ManaRequirement, untappedMana)
 
-	return false
+	return ok
 
 }
 
 // Denormalized returns a server.PlayerState
 func (p *Player) Denormalized() *server.PlayerState {
+	return p.denormalize(true)
+}
+
+// denormalize returns a server.PlayerState, the hand is only included if hand is true
+// as working out which of the cards can be played runs the mana cost handlers
+func (p *Player) denormalize(hand bool) *server.PlayerState {
 
 	p.mutex.Lock()
 
@@ -511,22 +531,48 @@ func (p *Player) Denormalized() *server.PlayerState {
 		shields = append(shields, card.ID)
 	}
 
+	deck := len(p.deck)
+	handCards := append([]*Card{}, p.hand...)
+	manazone := append([]*Card{}, p.manazone...)
+	graveyard := append([]*Card{}, p.graveyard...)
+	battlezone := append([]*Card{}, p.battlezone...)
+
+	p.mutex.Unlock()
+
 	state := &server.PlayerState{
-		Deck:       len(p.deck),
-		HandCount:  len(p.hand),
-		Hand:       denormalizeCards(p.hand, false),
+		Deck:       deck,
+		HandCount:  len(handCards),
+		Hand:       make([]server.CardState, 0),
 		Shieldzone: shields,
-		Manazone:   denormalizeCards(p.manazone, false),
-		Graveyard:  denormalizeCards(p.graveyard, false),
-		Battlezone: denormalizeCards(p.battlezone, false),
+		Manazone:   denormalizeCards(manazone, false),
+		Graveyard:  denormalizeCards(graveyard, false),
+		Battlezone: denormalizeCards(battlezone, false),
 	}
 
-	p.mutex.Unlock()
+	// the hand is denormalized without holding the lock, calculating whether
+	// a card can be played runs the handlers of every card and they may lock it
+	if hand {
+		state.Hand = p.denormalizeHand(handCards, manazone)
+	}
 
 	return state
 
 }
 
+// denormalizeHand returns the cards in the hand as server.CardState, including
+// whether or not they can be played with the mana in the mana zone
+func (p *Player) denormalizeHand(cards []*Card, mana []*Card) []server.CardState {
+
+	arr := denormalizeCards(cards, false)
+
+	for i, card := range cards {
+		arr[i].CanBePlayed = p.CanPlayCard(card, mana)
+	}
+
+	return arr
+
+}
+
 // denormalizeCards takes an array of *Card and returns an array of server.CardState
 // if partial is true, the cards' name and image will not be included
 func denormalizeCards(cards []*Card, partial bool) []server.CardState {
@@ -535,29 +581,21 @@ func denormalizeCards(cards []*Card, partial bool) []server.CardState {
 
 	for _, card := range cards {
 
-		canBePlayed := true
-
-		mana, err := card.Player.Container(MANAZONE)
-
-		if err == nil {
-			canBePlayed = card.Player.CanPlayCard(card, mana)
-		}
-
 		cs := server.CardState{
-			CardID:      card.ID,
-			ImageID:     card.ImageID,
-			Name:        card.Name,
-			Civ:         card.Civ,
-			Tapped:      card.Tapped,
-			CanBePlayed: canBePlayed,
+			CardID:  card.ID,
+			ImageID: card.ImageID,
+			Name:    card.Name,
+			Civ:     card.Civ,
+			Civs:    card.Civilizations(),
+			Tapped:  card.Tapped,
 		}
 
 		if partial {
 			cs.ImageID = "backside"
 			cs.Name = ""
 			cs.Civ = "water" // blue highlight color when selected in actions
+			cs.Civs = []string{cs.Civ}
 			cs.Tapped = false
-			cs.CanBePlayed = false
 		}
 
 		arr = append(arr, cs)
@@ -569,7 +607,7 @@ func denormalizeCards(cards []*Card, partial bool) []server.CardState {
 
 // Username returns the username of the player
 func (p *Player) Username() string {
-	return p.match.PlayerRef(p).Socket.User.Username
+	return p.match.PlayerRef(p).Username
 }
 
 // Dispose clears out references in the player object
@@ -579,8 +617,6 @@ func (p *Player) Dispose() {
 
 	defer p.mutex.Unlock()
 
-	close(p.Action)
-
 	for _, c := range p.deck {
 		c.Player = nil
 	}
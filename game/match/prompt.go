@@ -0,0 +1,262 @@
+package match
+
+import (
+	"duel-masters/server"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PromptState tells whether a player can make moves or has to wait for a prompt to be answered
+type PromptState int
+
+const (
+	// PromptIdle means that no prompt is pending and the player can make moves
+	PromptIdle PromptState = iota
+	// PromptResponding means that the player has to respond to their prompt first
+	PromptResponding
+	// PromptWaiting means that the player's opponent has to respond to their prompt first
+	PromptWaiting
+)
+
+// prompt is an action prompt that is waiting for a response from the player
+type prompt struct {
+	msg         interface{}
+	cards       []string
+	min         int
+	max         int
+	cancellable bool
+}
+
+// setPrompt stores the prompt that was last sent to the player, so it can be sent again
+// when the player reconnects. Spectators are shown that the player is making a choice
+func (m *Match) setPrompt(p *Player, msg interface{}, cards []string, min int, max int, cancellable bool) {
+
+	m.promptMutex.Lock()
+
+	p.prompt = &prompt{
+		msg:         msg,
+		cards:       cards,
+		min:         min,
+		max:         max,
+		cancellable: cancellable,
+	}
+
+	m.promptMutex.Unlock()
+
+	m.clockWaitFor(p)
+
+	m.sendSpectators(m.choosingMessage(p))
+
+}
+
+// clearPrompt removes the stored prompt of the player after it was closed
+func (m *Match) clearPrompt(p *Player) {
+
+	m.promptMutex.Lock()
+
+	pending := p.prompt
+	p.prompt = nil
+
+	m.promptMutex.Unlock()
+
+	// a response that was not read is meant for the closed prompt
+	p.response = nil
+
+	m.clockWaitFor(nil)
+
+	if pending != nil {
+		m.sendSpectators(server.Message{
+			Header: "end_wait",
+		})
+	}
+
+}
+
+// setWait stores the text of the waiting popup that is shown to the player, or
+// removes it if the text is empty
+func (m *Match) setWait(p *Player, text string) {
+
+	m.promptMutex.Lock()
+	defer m.promptMutex.Unlock()
+
+	p.wait = text
+
+}
+
+// resendPrompt sends the waiting popup and the pending prompt of the player again,
+// e.g. after the player reconnected. The card that sent the prompt is still waiting
+// for the response
+func (m *Match) resendPrompt(ref *PlayerReference) {
+
+	if ref.Controller == nil {
+		return
+	}
+
+	m.promptMutex.Lock()
+	pending := ref.Player.prompt
+	wait := ref.Player.wait
+	m.promptMutex.Unlock()
+
+	if wait != "" {
+		ref.Controller.Send(server.WaitMessage{
+			Header:  "wait",
+			Message: wait,
+		})
+	}
+
+	if pending != nil {
+		ref.Controller.Send(pending.msg)
+	}
+
+}
+
+// resendChoosing shows the spectator which players are making a choice
+func (m *Match) resendChoosing(s *server.Socket) {
+
+	for _, ref := range []*PlayerReference{m.Player1, m.Player2} {
+		if ref != nil && m.pendingPrompt(ref.Player) != nil {
+			s.Send(m.choosingMessage(ref.Player))
+		}
+	}
+
+}
+
+// choosingMessage returns the read-only popup that is shown to spectators while the player
+// is responding to a prompt
+func (m *Match) choosingMessage(p *Player) server.WaitMessage {
+	return server.WaitMessage{
+		Header:  "wait",
+		Message: fmt.Sprintf("%s is making a choice", m.PlayerRef(p).Username),
+	}
+}
+
+// pendingPrompt returns the prompt the player has not yet responded to, or nil
+func (m *Match) pendingPrompt(p *Player) *prompt {
+
+	m.promptMutex.Lock()
+	defer m.promptMutex.Unlock()
+
+	return p.prompt
+
+}
+
+// HasPendingPrompt returns true if the player was sent a prompt they have not yet responded to
+func (m *Match) HasPendingPrompt(p *Player) bool {
+	return m.pendingPrompt(p) != nil
+}
+
+// PromptState returns whether the player can make moves or has to wait for a prompt to be answered
+func (m *Match) PromptState(p *Player) PromptState {
+
+	if m.HasPendingPrompt(p) {
+		return PromptResponding
+	}
+
+	if m.HasPendingPrompt(m.Opponent(p)) {
+		return PromptWaiting
+	}
+
+	return PromptIdle
+
+}
+
+// allowCommand returns false and warns the player if they can't make a move before a prompt
+// was answered. The move would otherwise start while the card that sent the prompt is not done
+func (m *Match) allowCommand(s *server.Socket) bool {
+
+	p, err := m.PlayerForSocket(s)
+
+	if err != nil {
+		return true
+	}
+
+	switch m.PromptState(p.Player) {
+
+	case PromptResponding:
+		m.WarnPlayer(p.Player, "You have to respond to the card selection first")
+		return false
+
+	case PromptWaiting:
+		m.WarnPlayer(p.Player, "Your opponent has to make a choice first")
+		return false
+
+	}
+
+	return true
+
+}
+
+// closeStalePrompts closes the prompts that are still pending after an event was handled.
+// Responses are only read while the event that sent the prompt is running, so nobody
+// is waiting for them anymore
+func (m *Match) closeStalePrompts() {
+
+	for _, ref := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if ref == nil || !m.HasPendingPrompt(ref.Player) {
+			continue
+		}
+
+		logrus.Debugf("Closing the prompt of %s in match %s that was not waited for", ref.Username, m.ID)
+
+		m.CloseAction(ref.Player)
+
+	}
+
+}
+
+// DefaultAction returns the response that is made on the player's behalf when the prompt
+// can't wait for them. The prompt is cancelled if possible, otherwise the minimum number
+// of cards is selected. It has to be called from the loop of the match
+func (m *Match) DefaultAction(p *Player) PlayerAction {
+
+	pending := m.pendingPrompt(p)
+
+	if pending == nil || pending.cancellable {
+		return PlayerAction{Cancel: true}
+	}
+
+	n := pending.min
+	if n > len(pending.cards) {
+		n = len(pending.cards)
+	}
+
+	return PlayerAction{Cards: pending.cards[:n]}
+
+}
+
+// cardIDs returns the ids of the cards
+func cardIDs(cards []*Card) []string {
+
+	result := make([]string, 0)
+
+	for _, c := range cards {
+		result = append(result, c.ID)
+	}
+
+	return result
+
+}
+
+// multipartCardIDs returns the ids of the cards, ordered by group name
+func multipartCardIDs(cards map[string][]*Card) []string {
+
+	keys := make([]string, 0)
+
+	for key := range cards {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	result := make([]string, 0)
+
+	for _, key := range keys {
+		result = append(result, cardIDs(cards[key])...)
+	}
+
+	return result
+
+}
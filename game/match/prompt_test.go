@@ -0,0 +1,131 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"reflect"
+	"testing"
+)
+
+// responder is a controller that answers every prompt with the same response and
+// records the prompt states of both players when it is prompted
+type responder struct {
+	recorder
+	match    *match.Match
+	player   *match.PlayerReference
+	response match.PlayerAction
+	states   []match.PromptState
+}
+
+func (r *responder) Send(msg interface{}) {
+
+	r.recorder.Send(msg)
+
+	if _, ok := msg.(*server.ActionMessage); !ok {
+		return
+	}
+
+	r.states = []match.PromptState{
+		r.match.PromptState(r.match.Player1.Player),
+		r.match.PromptState(r.match.Player2.Player),
+	}
+
+	go r.player.Player.Respond(r.response)
+
+}
+
+// newPromptMatch returns a headless match where player1 answers prompts with the response
+func newPromptMatch(response match.PlayerAction) (*match.Match, *responder) {
+
+	m := match.NewHeadless("prompt")
+
+	r := &responder{match: m, response: response}
+	r.player, _ = m.AddPlayer("p1", "p1", r)
+
+	m.AddPlayer("p2", "p2", &recorder{})
+
+	return m, r
+
+}
+
+func TestPromptState(t *testing.T) {
+
+	m, r := newPromptMatch(match.PlayerAction{Cancel: true})
+	defer m.Dispose()
+
+	var before, after []match.PromptState
+
+	m.Do(func() {
+		before = []match.PromptState{m.PromptState(m.Player1.Player), m.PromptState(m.Player2.Player)}
+
+		m.NewAction(m.Player1.Player, nil, 0, 1, "Select a card", true)
+		m.Player1.Player.WaitForAction()
+		m.CloseAction(m.Player1.Player)
+
+		after = []match.PromptState{m.PromptState(m.Player1.Player), m.PromptState(m.Player2.Player)}
+	})
+
+	idle := []match.PromptState{match.PromptIdle, match.PromptIdle}
+
+	if !reflect.DeepEqual(before, idle) || !reflect.DeepEqual(after, idle) {
+		t.Errorf("Expected both players to be idle without a prompt, got %v before and %v after", before, after)
+	}
+
+	if expected := []match.PromptState{match.PromptResponding, match.PromptWaiting}; !reflect.DeepEqual(r.states, expected) {
+		t.Errorf("Expected player1 to be responding and player2 to be waiting, got %v", r.states)
+	}
+
+}
+
+func TestStrayResponseIsDropped(t *testing.T) {
+
+	m, _ := newPromptMatch(match.PlayerAction{Cards: []string{"response"}})
+	defer m.Dispose()
+
+	m.Player1.Player.Respond(match.PlayerAction{Cards: []string{"stray"}})
+
+	var action match.PlayerAction
+
+	m.Do(func() {
+		m.NewAction(m.Player1.Player, nil, 1, 1, "Select a card", false)
+		action = m.Player1.Player.WaitForAction()
+		m.CloseAction(m.Player1.Player)
+	})
+
+	if !reflect.DeepEqual(action.Cards, []string{"response"}) {
+		t.Errorf("Expected the response to the prompt, got %v", action.Cards)
+	}
+
+}
+
+func TestStalePromptIsClosed(t *testing.T) {
+
+	m, r := newPromptMatch(match.PlayerAction{Cancel: true})
+	defer m.Dispose()
+
+	// the prompt is never waited for, e.g. because a card forgot to close it
+	m.Do(func() {
+		m.NewAction(m.Player1.Player, nil, 0, 1, "Select a card", true)
+	})
+
+	pending := true
+
+	m.Do(func() { pending = m.HasPendingPrompt(m.Player1.Player) })
+
+	if pending {
+		t.Error("Expected the prompt to be closed after the command that sent it")
+	}
+
+	closed := false
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.Message); ok && msg.Header == "close_action" {
+			closed = true
+		}
+	}
+
+	if !closed {
+		t.Errorf("Expected the player to be sent close_action, got %v", r.Messages())
+	}
+
+}
@@ -0,0 +1,208 @@
+package match
+
+import (
+	"duel-masters/server"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RematchTime is how long the players can ask for a rematch after the game ended,
+// the match is closed afterwards
+var RematchTime = 2 * time.Minute
+
+// rematchVersion is the protocol version that added concede, draw offers and rematches
+const rematchVersion = 3
+
+// requeueVersion is the protocol version that added requeue
+const requeueVersion = 3
+
+// drawOffer is a draw that was offered by the player with the uid during the turn
+type drawOffer struct {
+	uid  string
+	turn int
+}
+
+// Concede ends the game with the player's opponent as the winner
+func (m *Match) Concede(p *PlayerReference) {
+
+	if !m.Started || m.ending {
+		return
+	}
+
+	o := m.opponentRef(p)
+
+	m.End(o.Player, Concede, fmt.Sprintf("%s conceded, %s won the game", p.Username, o.Username))
+
+}
+
+// OfferDraw offers the player's opponent a draw, the offer stands until the end of the turn
+func (m *Match) OfferDraw(p *PlayerReference) {
+
+	if !m.Started || m.ending {
+		return
+	}
+
+	if m.drawOffer != nil && m.drawOffer.uid == p.UID && m.drawOffer.turn == m.turns {
+		return
+	}
+
+	m.drawOffer = &drawOffer{uid: p.UID, turn: m.turns}
+
+	m.Chat("Server", fmt.Sprintf("%s offers a draw until the end of the turn", p.Username))
+
+	m.sendSince(m.opponentRef(p), rematchVersion, server.Message{Header: "draw_offered"})
+
+}
+
+// AcceptDraw ends the game in a draw if the player's opponent offered one during the turn
+func (m *Match) AcceptDraw(p *PlayerReference) {
+
+	if !m.Started || m.ending {
+		return
+	}
+
+	if m.drawOffer == nil || m.drawOffer.uid == p.UID || m.drawOffer.turn != m.turns {
+		m.WarnPlayer(p.Player, "Your opponent has not offered a draw this turn")
+		return
+	}
+
+	m.End(nil, Draw, "The game ended in a draw")
+
+}
+
+// Rematch asks for a rematch after the game ended. Once both players asked for it a new
+// match between them is created, where the player who went second takes the first turn
+func (m *Match) Rematch(p *PlayerReference) {
+
+	if m.ended.IsZero() || m.stopping {
+		m.WarnPlayer(p.Player, "You can only ask for a rematch once the game is over")
+		return
+	}
+
+	if m.rematches[p.UID] {
+		return
+	}
+
+	m.rematches[p.UID] = true
+
+	o := m.opponentRef(p)
+
+	if !m.rematches[o.UID] {
+		m.Chat("Server", fmt.Sprintf("%s wants a rematch", p.Username))
+		m.sendSince(o, rematchVersion, server.Message{Header: "rematch_offered"})
+		return
+	}
+
+	m.startRematch()
+
+}
+
+// canRematch returns true if the players can ask for a rematch once the game ended,
+// games of a series are followed by the next game instead
+func (m *Match) canRematch() bool {
+	return m.Started && !m.headless && !m.computer && m.series == nil &&
+		m.Player1.Controller != nil && m.Player2.Controller != nil
+}
+
+// startRematch creates the match for the rematch and sends the players and spectators to it
+func (m *Match) startRematch() {
+
+	first := m.Player1.UID
+
+	if m.first == first {
+		first = m.Player2.UID
+	}
+
+	r := m.newGame(first)
+
+	logrus.Debugf("Created rematch %s of match %s", r.ID, m.ID)
+
+	m.broadcastSince(rematchVersion, server.MatchFoundMessage{
+		Header: "rematch",
+		ID:     r.ID,
+	})
+
+	m.stop()
+
+}
+
+// newGame creates a match for another game between the players, where the player with
+// the uid goes first. The players choose their decks again, the previous ones are suggested
+func (m *Match) newGame(first string) *Match {
+
+	previous := map[string]string{
+		m.Player1.UID: m.Player1.Deck,
+		m.Player2.UID: m.Player2.Deck,
+	}
+
+	guest := m.Player2.UID
+	clock := m.clock
+	series := m.series
+
+	r := New(m.MatchName, m.HostID, m.Visible)
+
+	r.Do(func() {
+		r.GuestID = guest
+		r.SetClock(clock)
+		r.SetFirstPlayer(first)
+		r.SetSeries(series)
+		r.previous = previous
+	})
+
+	return r
+
+}
+
+// opponentRef returns the reference of the player's opponent
+func (m *Match) opponentRef(p *PlayerReference) *PlayerReference {
+
+	if m.Player1 == p {
+		return m.Player2
+	}
+
+	return m.Player1
+
+}
+
+// supports returns true if the controller negotiated at least the protocol version,
+// local controllers can be sent every message
+func supports(c PlayerController, version int) bool {
+
+	s, ok := c.(*server.Socket)
+
+	return !ok || s.Version >= version
+
+}
+
+// sendSince sends the message to the player if their controller negotiated at least the protocol version
+func (m *Match) sendSince(p *PlayerReference, version int, msg interface{}) {
+
+	if p.Controller == nil || !supports(p.Controller, version) {
+		return
+	}
+
+	p.Controller.Send(msg)
+
+}
+
+// broadcastSince sends the message to the players and spectators that negotiated at least the protocol version
+func (m *Match) broadcastSince(version int, msg interface{}) {
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+		if p != nil {
+			m.sendSince(p, version, msg)
+		}
+	}
+
+	m.spectators.RLock()
+	defer m.spectators.RUnlock()
+
+	for _, spectator := range m.spectators.users {
+		if spectator.Socket != nil && spectator.Socket.Version >= version {
+			spectator.Socket.Send(msg)
+		}
+	}
+
+}
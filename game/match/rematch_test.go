@@ -0,0 +1,203 @@
+package match_test
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"strings"
+	"testing"
+)
+
+// startMatch adds two players with recorders to the match and starts it
+func startMatch(m *match.Match) (*recorder, *recorder) {
+
+	r1 := &recorder{}
+	r2 := &recorder{}
+
+	m.Do(func() {
+		p1, _ := m.AddPlayer("p1", "p1", r1)
+		p2, _ := m.AddPlayer("p2", "p2", r2)
+
+		p1.Player.CreateDeck(testDeck())
+		p2.Player.CreateDeck(testDeck())
+
+		m.Start()
+	})
+
+	return r1, r2
+
+}
+
+// gameOver returns the game_over message the recorder was sent, or nil
+func gameOver(r *recorder) *server.GameOverMessage {
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.GameOverMessage); ok {
+			return &msg
+		}
+	}
+
+	return nil
+
+}
+
+// warned returns true if the recorder was sent a warning containing the text
+func warned(r *recorder, text string) bool {
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.WarningMessage); ok && msg.Header == "warn" && strings.Contains(msg.Message, text) {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+func TestConcede(t *testing.T) {
+
+	m := match.NewHeadless("concede")
+	defer m.Dispose()
+
+	r1, _ := startMatch(m)
+
+	m.Do(func() { m.Concede(m.Player1) })
+
+	result := gameOver(r1)
+
+	if result == nil || result.Winner != "p2" || result.Reason != match.Concede || result.Rematch {
+		t.Errorf("Expected p2 to win after p1 conceded without a rematch in a headless match, got %+v", result)
+	}
+
+}
+
+func TestDraw(t *testing.T) {
+
+	m := match.NewHeadless("draw")
+	defer m.Dispose()
+
+	r1, r2 := startMatch(m)
+
+	m.Do(func() {
+		m.AcceptDraw(m.Player2)
+		m.OfferDraw(m.Player1)
+		m.AcceptDraw(m.Player1)
+	})
+
+	if !warned(r2, "not offered a draw") || !warned(r1, "not offered a draw") {
+		t.Error("Expected a draw to only be accepted after the opponent offered it")
+	}
+
+	if gameOver(r1) != nil {
+		t.Fatal("Expected the game not to end before the draw was accepted")
+	}
+
+	m.Do(func() { m.AcceptDraw(m.Player2) })
+
+	result := gameOver(r1)
+
+	if result == nil || result.Winner != "" || result.Reason != match.Draw {
+		t.Errorf("Expected the game to end in a draw, got %+v", result)
+	}
+
+}
+
+func TestDrawOfferExpires(t *testing.T) {
+
+	m := match.NewHeadless("draw")
+	defer m.Dispose()
+
+	r1, r2 := startMatch(m)
+
+	m.Do(func() {
+		m.OfferDraw(m.Player1)
+		m.EndTurn()
+		m.AcceptDraw(m.Player2)
+	})
+
+	if !warned(r2, "not offered a draw") || gameOver(r1) != nil {
+		t.Error("Expected the draw offer to be withdrawn at the end of the turn")
+	}
+
+}
+
+func TestFirstPlayer(t *testing.T) {
+
+	m := match.NewHeadless("first")
+	defer m.Dispose()
+
+	m.SetFirstPlayer("p2")
+
+	startMatch(m)
+
+	current := ""
+
+	m.Do(func() { current = m.CurrentPlayer().UID })
+
+	if current != "p2" || m.FirstPlayer() != "p2" {
+		t.Errorf("Expected p2 to take the first turn, %s did", current)
+	}
+
+}
+
+func TestRematch(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	// the lobby is not running, the match list updates are dropped
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	m := match.New("rematch", "p1", false)
+	defer m.Dispose()
+
+	r1, r2 := startMatch(m)
+
+	first := m.FirstPlayer()
+
+	m.Do(func() {
+		m.Concede(m.Player1)
+		m.Rematch(m.Player1)
+	})
+
+	if result := gameOver(r2); result == nil || !result.Rematch {
+		t.Fatalf("Expected the players to be able to ask for a rematch, got %+v", result)
+	}
+
+	offered := false
+
+	for _, msg := range r2.Messages() {
+		if msg, ok := msg.(server.Message); ok && msg.Header == "rematch_offered" {
+			offered = true
+		}
+	}
+
+	if !offered {
+		t.Error("Expected the opponent to be told about the rematch")
+	}
+
+	m.Do(func() { m.Rematch(m.Player2) })
+
+	var id string
+
+	for _, msg := range r1.Messages() {
+		if msg, ok := msg.(server.MatchFoundMessage); ok && msg.Header == "rematch" {
+			id = msg.ID
+		}
+	}
+
+	rematch, err := match.Find(id)
+
+	if err != nil {
+		t.Fatalf("Expected the rematch to be created, got %v", err)
+	}
+
+	defer rematch.Dispose()
+
+	if rematch.FirstPlayer() == first || rematch.FirstPlayer() == "" {
+		t.Errorf("Expected the player who went second to take the first turn of the rematch, %s went first before", first)
+	}
+
+}
@@ -0,0 +1,158 @@
+package match
+
+import (
+	"bytes"
+	"duel-masters/db"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxReplayEvents limits the size of a replay to stay well below mongodb's document size limit
+const maxReplayEvents = 5000
+
+// replayedMessages are the messages from players that affect the outcome of a match
+// and are recorded in the replay
+var replayedMessages = map[string]bool{
+	"add_to_manazone": true,
+	"add_to_playzone": true,
+	"action":          true,
+	"attack_player":   true,
+	"attack_creature": true,
+	"end_turn":        true,
+	"chat":            true,
+	"concede":         true,
+	"accept_draw":     true,
+}
+
+// recorder keeps the ordered event log of a match
+type recorder struct {
+	created   time.Time
+	players   []db.ReplayPlayer
+	events    []db.ReplayEvent
+	lastState []byte
+	full      bool
+	mutex     sync.Mutex
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		created: time.Now(),
+		players: make([]db.ReplayPlayer, 0),
+		events:  make([]db.ReplayEvent, 0),
+	}
+}
+
+// addPlayer records a player and the cards of their deck, in the order the decks are created
+func (r *recorder) addPlayer(p *PlayerReference, cards []string) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.players = append(r.players, db.ReplayPlayer{
+		UID:      p.UID,
+		Username: p.Username,
+		Deck:     p.Deck,
+		Cards:    cards,
+	})
+
+}
+
+// recordMessage records a message received from a player
+func (r *recorder) recordMessage(player string, header string, data []byte) {
+
+	msg := make([]byte, len(data))
+	copy(msg, data)
+
+	r.add(player, header, msg)
+
+}
+
+// recordState records a state update, states identical to the previous one are skipped
+func (r *recorder) recordState(state interface{}) {
+
+	data, err := json.Marshal(state)
+
+	if err != nil {
+		logrus.Debugf("Failed to record state. %v", err)
+		return
+	}
+
+	r.mutex.Lock()
+	same := bytes.Equal(data, r.lastState)
+	r.lastState = data
+	r.mutex.Unlock()
+
+	if same {
+		return
+	}
+
+	r.add("", "state_update", data)
+
+}
+
+// recordChat records a chat message sent to the players
+func (r *recorder) recordChat(msg interface{}) {
+
+	data, err := json.Marshal(msg)
+
+	if err != nil {
+		logrus.Debugf("Failed to record chat message. %v", err)
+		return
+	}
+
+	r.add("", "chat", data)
+
+}
+
+func (r *recorder) add(player string, header string, data []byte) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.events) >= maxReplayEvents {
+		r.full = true
+		return
+	}
+
+	r.events = append(r.events, db.ReplayEvent{
+		Time:   time.Since(r.created).Milliseconds(),
+		Player: player,
+		Header: header,
+		Data:   data,
+	})
+
+}
+
+// saveReplay stores the recorded event log of the match in the database
+func (m *Match) saveReplay() {
+
+	if !m.Started || m.headless {
+		return
+	}
+
+	m.replay.mutex.Lock()
+
+	replay := db.Replay{
+		UID:     m.ID,
+		Name:    m.MatchName,
+		Seed:    m.seed,
+		Players: m.replay.players,
+		Events:  m.replay.events,
+		Started: m.started,
+		Ended:   time.Now().Unix(),
+	}
+
+	if m.replay.full {
+		logrus.Warnf("Replay of match %s exceeded %v events and was cut short", m.ID, maxReplayEvents)
+	}
+
+	m.replay.mutex.Unlock()
+
+	if err := db.SaveReplay(replay); err != nil {
+		logrus.Errorf("Failed to save replay of match %s. %v", m.ID, err)
+	}
+
+}
@@ -0,0 +1,89 @@
+package match_test
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestReplay records a match, then plays back its event log and compares the last state
+// of the replay with the state the match ended in
+func TestReplay(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	m := match.New("replay", "p1", false)
+
+	startMatch(m)
+
+	var p1, p2 server.PlayerState
+
+	m.Do(func() {
+
+		for i := 0; i < 4; i++ {
+
+			p := m.CurrentPlayer()
+			hand, _ := p.Player.Container(match.HAND)
+
+			m.ChargeMana(p, hand[0].ID)
+			m.EndTurn()
+
+		}
+
+		p1 = *m.Player1.Player.Denormalized()
+		p2 = *m.Player2.Player.Denormalized()
+
+		m.Concede(m.Player1)
+
+	})
+
+	m.Dispose()
+
+	replay, err := db.GetReplay(m.ID)
+
+	if err != nil {
+		t.Fatalf("Expected the replay to be saved, got %v", err)
+	}
+
+	var last *server.MatchStateMessage
+
+	for _, event := range replay.Events {
+
+		if event.Header != "state_update" {
+			continue
+		}
+
+		state := &server.MatchStateMessage{}
+
+		if err := json.Unmarshal(event.Data, state); err != nil {
+			t.Fatalf("Expected the recorded state to be valid, got %v", err)
+		}
+
+		last = state
+
+	}
+
+	if last == nil {
+		t.Fatal("Expected the replay to contain states")
+	}
+
+	last.State.Me.Username, last.State.Me.Color = "", ""
+	last.State.Opponent.Username, last.State.Opponent.Color = "", ""
+
+	if !reflect.DeepEqual(last.State.Me, p1) || !reflect.DeepEqual(last.State.Opponent, p2) {
+		t.Errorf("Expected the replay to end in the final state of the match\ngot  %+v %+v\nwant %+v %+v", last.State.Me, last.State.Opponent, p1, p2)
+	}
+
+	if len(p1.Manazone)+len(p2.Manazone) != 4 {
+		t.Errorf("Expected 4 cards to be charged as mana, got %v", len(p1.Manazone)+len(p2.Manazone))
+	}
+
+}
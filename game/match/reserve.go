@@ -0,0 +1,92 @@
+package match
+
+import (
+	"duel-masters/server"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JoinTime is how long the users a match was reserved for have to join it
+var JoinTime = 30 * time.Second
+
+// seats maps the uids of the users who joined a match, or had one reserved for them,
+// to the id of the match. It is protected by matchesMutex
+var seats = make(map[string]string)
+
+// InMatch returns true if the user with the uid is playing a match or a match is reserved for them
+func InMatch(uid string) bool {
+
+	matchesMutex.Lock()
+	defer matchesMutex.Unlock()
+
+	_, ok := seats[uid]
+
+	return ok
+
+}
+
+// seat marks the user as playing the match
+func (m *Match) seat(uid string) {
+
+	matchesMutex.Lock()
+	defer matchesMutex.Unlock()
+
+	seats[uid] = m.ID
+
+}
+
+// releaseSeats marks the users of the match as no longer playing it
+func (m *Match) releaseSeats() {
+
+	matchesMutex.Lock()
+	defer matchesMutex.Unlock()
+
+	for uid, id := range seats {
+		if id == m.ID {
+			delete(seats, uid)
+		}
+	}
+
+}
+
+// Reserve keeps the match for the host and the guest, nobody else can join it. If either
+// of them has not joined within JoinTime the match is closed, and the user who did join
+// is told to look for another opponent
+func (m *Match) Reserve(guest string) {
+
+	m.GuestID = guest
+	m.joinBy = time.Now().Add(JoinTime)
+
+	m.seat(m.HostID)
+	m.seat(guest)
+
+}
+
+// checkReserved closes a reserved match that one of the users did not join in time
+func (m *Match) checkReserved() {
+
+	if m.joinBy.IsZero() || m.stopping || (m.Player1 != nil && m.Player2 != nil) || time.Now().Before(m.joinBy) {
+		return
+	}
+
+	logrus.Debugf("Closing match %s, it was not joined in time", m.ID)
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if p == nil {
+			continue
+		}
+
+		m.send(p.Player, server.WarningMessage{
+			Header:  "error",
+			Message: "Your opponent did not join the match",
+		})
+
+		m.sendSince(p, requeueVersion, server.Message{Header: "requeue"})
+
+	}
+
+	m.stop()
+
+}
@@ -0,0 +1,66 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"testing"
+	"time"
+)
+
+func TestReservedMatchNotJoined(t *testing.T) {
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	joinTime := match.JoinTime
+	match.JoinTime = 0
+	defer func() { match.JoinTime = joinTime }()
+
+	m := match.New("queued", "p1", false)
+	defer m.Dispose()
+
+	r := &recorder{}
+
+	m.Do(func() {
+		m.AddPlayer("p1", "p1", r)
+		m.Reserve("p2")
+	})
+
+	if !match.InMatch("p1") || !match.InMatch("p2") {
+		t.Error("Expected both users to be playing the reserved match")
+	}
+
+	// the reservation is checked every second
+	for i := 0; i < 30; i++ {
+
+		if _, err := match.Find(m.ID); err != nil {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+
+	}
+
+	if _, err := match.Find(m.ID); err == nil {
+		t.Fatal("Expected the match to be closed once p2 did not join in time")
+	}
+
+	requeued := false
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.Message); ok && msg.Header == "requeue" {
+			requeued = true
+		}
+	}
+
+	if !requeued {
+		t.Errorf("Expected p1 to be told to look for another opponent, got %v", r.Messages())
+	}
+
+	if match.InMatch("p1") || match.InMatch("p2") {
+		t.Error("Expected the users to no longer be playing the match")
+	}
+
+}
@@ -0,0 +1,88 @@
+package match
+
+import (
+	"duel-masters/db"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reasons for a match to end
+const (
+	ShieldsBroken = "shields_broken"
+	DeckOut       = "deck_out"
+	Disconnect    = "disconnect"
+	Forfeit       = "forfeit"
+	Timeout       = "timeout"
+	Concede       = "concede"
+	Draw          = "draw"
+)
+
+// saveResult stores the outcome of the match in the database
+// winner is nil if the match ended without a winner. If storing the outcome fails
+// it is stored again the next time saveResult is called, i.e. when the match is disposed
+func (m *Match) saveResult(winner *Player, reason string) {
+
+	if !m.Started || m.resultSaved || m.headless || m.computer {
+		return
+	}
+
+	if m.result == nil {
+		m.result = m.newResult(winner, reason)
+	}
+
+	if err := db.SaveMatch(*m.result); err != nil {
+		logrus.Errorf("Failed to save result of match %s. %v", m.ID, err)
+		return
+	}
+
+	m.resultSaved = true
+
+}
+
+// newResult returns the outcome of the match to be stored
+func (m *Match) newResult(winner *Player, reason string) *db.Match {
+
+	result := &db.Match{
+		UID:       m.ID,
+		Name:      m.MatchName,
+		Players:   make([]db.MatchPlayer, 0),
+		WinReason: reason,
+		Draw:      reason == Draw,
+		Turns:     m.turns,
+		Seed:      m.seed,
+		Started:   m.started,
+		Ended:     time.Now().Unix(),
+	}
+
+	if m.series != nil {
+
+		result.Series = m.series.ID
+
+		if m.series.over() {
+			result.SeriesResult = m.series.result()
+		}
+
+	}
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		if p == nil {
+			continue
+		}
+
+		result.Players = append(result.Players, db.MatchPlayer{
+			UID:      p.UID,
+			Username: p.Username,
+			Deck:     p.Deck,
+		})
+
+		if winner != nil && p.Player == winner {
+			result.Winner = p.UID
+		}
+
+	}
+
+	return result
+
+}
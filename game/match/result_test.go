@@ -0,0 +1,59 @@
+package match_test
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"errors"
+	"testing"
+)
+
+// matchStore fails to save the first results and remembers the ones that were saved
+type matchStore struct {
+	failures int
+	saved    []db.Match
+}
+
+func (s *matchStore) Save(m db.Match) error {
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+
+	s.saved = append(s.saved, m)
+
+	return nil
+
+}
+
+func TestResultSavedAgainAfterFailure(t *testing.T) {
+
+	matches := &matchStore{failures: 1}
+
+	storage := db.NewMemoryStorage()
+	storage.Matches = matches
+
+	db.Use(storage)
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	m := match.New("result", "p1", false)
+
+	startMatch(m)
+
+	m.Do(func() { m.Concede(m.Player1) })
+
+	m.Dispose()
+
+	if len(matches.saved) != 1 {
+		t.Fatalf("Expected the result to be saved once when the match was disposed, got %v", len(matches.saved))
+	}
+
+	if result := matches.saved[0]; result.WinReason != match.Concede || result.Winner != "p2" {
+		t.Errorf("Expected the result of the concession to be saved, got %+v", result)
+	}
+
+}
@@ -0,0 +1,93 @@
+package match
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_-"
+
+// lockedSource is a rand.Source that is safe for concurrent use,
+// as match events can be handled from several goroutines
+type lockedSource struct {
+	src   rand.Source64
+	mutex sync.Mutex
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.src.Seed(seed)
+}
+
+// newRand returns a seeded, concurrency safe *rand.Rand
+func newRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+// newSeed returns a seed for a new match. The seed can't be derived from the time
+// the match was created, knowing it would reveal the order of both decks
+func newSeed() int64 {
+
+	var b [8]byte
+
+	if _, err := crand.Read(b[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+
+	return int64(binary.LittleEndian.Uint64(b[:]))
+
+}
+
+// Seed returns the seed of the match's random number generator
+func (m *Match) Seed() int64 {
+	return m.seed
+}
+
+// SetSeed reseeds the match's random number generator. A match played with the
+// same seed and the same player actions plays out exactly the same, as long as
+// the seed is set before any cards are added to the match
+func (m *Match) SetSeed(seed int64) {
+	m.seed = seed
+	m.rng.Seed(seed)
+}
+
+// Rand returns the match's random number generator, every random outcome of the
+// match, such as shuffles and random discards, must use it to keep matches replayable
+func (m *Match) Rand() *rand.Rand {
+	return m.rng
+}
+
+// newID returns a random id for a card. The ids are seen by the players, so they are
+// not drawn from the match's random number generator as they would reveal its state
+func newID() (string, error) {
+
+	id := make([]byte, 10)
+
+	if _, err := crand.Read(id); err != nil {
+		return "", err
+	}
+
+	// the alphabet has 64 characters, so every byte maps to one without bias
+	for i := range id {
+		id[i] = idAlphabet[id[i]%byte(len(idAlphabet))]
+	}
+
+	return string(id), nil
+
+}
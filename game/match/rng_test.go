@@ -0,0 +1,114 @@
+package match_test
+
+import (
+	"duel-masters/game/match"
+	"duel-masters/game/match/matchtest"
+	"testing"
+)
+
+var deck = []string{
+	"57eeb3c3-2561-4841-a381-2e50d17533d1",
+	"4d3201e8-0d9b-481e-b8e3-86cb90058e20",
+	"733a4f35-7470-40e3-9cd7-479aa965bfbb",
+	"94e5d2a3-d8b2-4fce-9899-61adb063dc60",
+	"4b021e6f-39cf-401e-89cf-f164f7c0a797",
+	"d1703c3b-8e49-4959-8322-ae11a7ca6632",
+	"da8d62d3-47b9-450e-961c-9bcc171ff0ee",
+	"1d72eb3e-5185-449a-a16f-391bd2338343",
+}
+
+// shuffledDeck returns the uids of the cards in a shuffled deck of a new match with the given seed
+func shuffledDeck(t *testing.T, seed int64) []string {
+
+	m := match.NewHeadless("seed")
+	defer m.Dispose()
+
+	m.SetSeed(seed)
+
+	p, err := m.AddPlayer("p1", "player1", match.NewScriptedController(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, uid := range deck {
+		if _, err := p.Player.PutCard(uid, match.DECK); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p.Player.ShuffleDeck()
+
+	cards, err := p.Player.Container(match.DECK)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make([]string, 0)
+
+	for _, c := range cards {
+		result = append(result, c.ImageID)
+	}
+
+	return result
+
+}
+
+func TestSeedReproducesMatch(t *testing.T) {
+
+	matchtest.Register()
+
+	a := shuffledDeck(t, 42)
+	b := shuffledDeck(t, 42)
+	c := shuffledDeck(t, 43)
+
+	same := true
+
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("Expected the same card at position %v with the same seed, got %s and %s", i, a[i], b[i])
+		}
+		if a[i] != c[i] {
+			same = false
+		}
+	}
+
+	if same {
+		t.Error("Expected a different deck with a different seed")
+	}
+
+}
+
+// TestCardIDsAreNotSeeded checks that the card ids, which are seen by the players,
+// don't reveal the seed of the match
+func TestCardIDsAreNotSeeded(t *testing.T) {
+
+	matchtest.Register()
+
+	ids := make([]string, 0)
+
+	for i := 0; i < 2; i++ {
+
+		m := match.NewHeadless("seed")
+		m.SetSeed(42)
+
+		p, _ := m.AddPlayer("p1", "player1", match.NewScriptedController(nil, nil))
+
+		card, err := p.Player.PutCard(deck[0], match.DECK)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, card.ID)
+
+		m.Dispose()
+
+	}
+
+	if ids[0] == ids[1] {
+		t.Errorf("Expected different card ids with the same seed, got %s twice", ids[0])
+	}
+
+}
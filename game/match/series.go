@@ -0,0 +1,301 @@
+package match
+
+import (
+	"duel-masters/db"
+	"duel-masters/server"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SeriesAbandoned is the reason of a series that ended because both players left
+const SeriesAbandoned = "abandoned"
+
+// Series is a best-of-N series of matches between the same two users. Each game is
+// played in its own match, the loser of a game chooses who goes first in the next one
+type Series struct {
+	ID    string
+	Games int
+
+	wins   map[string]int
+	winner string
+	reason string
+	mutex  sync.Mutex
+}
+
+// NewSeries returns a series that is won by the first player to win more than half of the games
+func NewSeries(games int) *Series {
+	return &Series{
+		ID:    uuid.New().String(),
+		Games: games,
+		wins:  make(map[string]int),
+	}
+}
+
+// Wins returns the number of games the user with the uid won in the series
+func (s *Series) Wins(uid string) int {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.wins[uid]
+
+}
+
+// Winner returns the uid of the user who won the series, or an empty string while it is running
+func (s *Series) Winner() string {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.winner
+
+}
+
+// Reason returns why the series ended before it was decided by the games, Forfeit
+// or SeriesAbandoned. It is empty otherwise
+func (s *Series) Reason() string {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reason
+
+}
+
+// finish ends the series while it is undecided. The user with the uid wins the
+// series by forfeit, or the series is abandoned if uid is empty
+func (s *Series) finish(uid string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.winner != "" || s.reason != "" {
+		return
+	}
+
+	if uid == "" {
+		s.reason = SeriesAbandoned
+		return
+	}
+
+	s.winner = uid
+	s.reason = Forfeit
+
+}
+
+// result returns the final result of the series to be stored
+func (s *Series) result() *db.SeriesResult {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := &db.SeriesResult{
+		UID:    s.ID,
+		Games:  s.Games,
+		Wins:   make(map[string]int),
+		Winner: s.winner,
+		Reason: s.reason,
+	}
+
+	for uid, wins := range s.wins {
+		result.Wins[uid] = wins
+	}
+
+	return result
+
+}
+
+// over returns true once the series was decided or abandoned
+func (s *Series) over() bool {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.winner != "" || s.reason != ""
+
+}
+
+// record adds the result of a game and returns true if the series was decided by it.
+// uid is empty if the game ended without a winner, it is then played again
+func (s *Series) record(uid string) bool {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if uid == "" || s.winner != "" {
+		return s.winner != ""
+	}
+
+	s.wins[uid]++
+
+	if s.wins[uid] > s.Games/2 {
+		s.winner = uid
+	}
+
+	return s.winner != ""
+
+}
+
+// SetSeries makes the match a game of the series, it has to be called before the match is started
+func (m *Match) SetSeries(s *Series) {
+	m.series = s
+}
+
+// seriesMessage returns the score of the series the match is part of
+func (m *Match) seriesMessage() server.SeriesMessage {
+
+	msg := server.SeriesMessage{
+		Header:  "series",
+		Games:   m.series.Games,
+		Players: make([]server.SeriesPlayerState, 0),
+		Reason:  m.series.Reason(),
+	}
+
+	winner := m.series.Winner()
+
+	for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+
+		msg.Players = append(msg.Players, server.SeriesPlayerState{
+			Username: p.Username,
+			Wins:     m.series.Wins(p.UID),
+		})
+
+		if p.UID == winner {
+			msg.Winner = p.Username
+		}
+
+	}
+
+	return msg
+
+}
+
+// seriesScore returns the score of the series as text, e.g. "Player1 leads the series 2-1"
+func (m *Match) seriesScore() string {
+
+	leader, other := m.Player1, m.Player2
+
+	if m.series.Wins(other.UID) > m.series.Wins(leader.UID) {
+		leader, other = other, leader
+	}
+
+	score := fmt.Sprintf("%v-%v", m.series.Wins(leader.UID), m.series.Wins(other.UID))
+
+	// a series won by forfeit can be won by the player who is behind
+	if m.series.Winner() == other.UID {
+		leader, other = other, leader
+		score = fmt.Sprintf("%v-%v", m.series.Wins(leader.UID), m.series.Wins(other.UID))
+	}
+
+	switch {
+	case m.series.Reason() == SeriesAbandoned:
+		return fmt.Sprintf("The best of %v series was abandoned at %s", m.series.Games, score)
+	case m.series.Reason() == Forfeit:
+		return fmt.Sprintf("%s won the best of %v series %s by forfeit", leader.Username, m.series.Games, score)
+	case m.series.Winner() != "":
+		return fmt.Sprintf("%s won the best of %v series %s", leader.Username, m.series.Games, score)
+	case m.series.Wins(leader.UID) == m.series.Wins(other.UID):
+		return fmt.Sprintf("The best of %v series is tied %s", m.series.Games, score)
+	default:
+		return fmt.Sprintf("%s leads the best of %v series %s", leader.Username, m.series.Games, score)
+	}
+
+}
+
+// endSeriesGame records the result of the game and returns true if the series continues
+// with another game. The loser of the game, or the player who went second if it had no
+// winner, is asked to choose who goes first in the next game
+func (m *Match) endSeriesGame(winner *Player) bool {
+
+	if m.series == nil || !m.Started {
+		return false
+	}
+
+	uid := ""
+
+	if winner != nil {
+		uid = m.PlayerRef(winner).UID
+	}
+
+	decided := m.series.record(uid)
+
+	// the players have to be there to choose and to play the next game, the player
+	// who stayed wins the series by forfeit
+	if !decided && (m.Player1.Controller == nil || m.Player2.Controller == nil) {
+
+		stayed := ""
+
+		for _, p := range []*PlayerReference{m.Player1, m.Player2} {
+			if p.Controller != nil {
+				stayed = p.UID
+			}
+		}
+
+		m.series.finish(stayed)
+		decided = true
+
+	}
+
+	m.Chat("Server", m.seriesScore())
+	m.broadcastSince(rematchVersion, m.seriesMessage())
+
+	if decided {
+		logrus.Infof("Series %s: %s", m.series.ID, m.seriesScore())
+		return false
+	}
+
+	chooser := m.Player1
+
+	if (winner != nil && m.Player1.Player == winner) || (winner == nil && m.first == m.Player1.UID) {
+		chooser = m.Player2
+	}
+
+	m.chooser = chooser.UID
+
+	m.Chat("Server", fmt.Sprintf("%s chooses who goes first in the next game", chooser.Username))
+	m.sendSince(chooser, rematchVersion, server.Message{Header: "choose_first"})
+
+	return true
+
+}
+
+// ChooseFirst starts the next game of the series once the loser of the game chose who goes
+// first. first is true if the player takes the first turn themselves
+func (m *Match) ChooseFirst(p *PlayerReference, first bool) {
+
+	if m.chooser != p.UID || m.stopping {
+		m.WarnPlayer(p.Player, "You can't choose who goes first in the next game")
+		return
+	}
+
+	uid := p.UID
+
+	if !first {
+		uid = m.opponentRef(p).UID
+	}
+
+	m.nextSeriesGame(uid)
+
+}
+
+// nextSeriesGame creates the match for the next game of the series, where the player
+// with the uid goes first, and sends the players and spectators to it
+func (m *Match) nextSeriesGame(first string) {
+
+	m.chooser = ""
+
+	r := m.newGame(first)
+
+	logrus.Debugf("Created game %s of series %s", r.ID, m.series.ID)
+
+	m.broadcastSince(rematchVersion, server.MatchFoundMessage{
+		Header: "next_game",
+		ID:     r.ID,
+	})
+
+	m.stop()
+
+}
@@ -0,0 +1,235 @@
+package match_test
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"testing"
+)
+
+// nextGame returns the match the recorder was sent to for the next game of the series
+func nextGame(t *testing.T, r *recorder) *match.Match {
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.MatchFoundMessage); ok && msg.Header == "next_game" {
+
+			m, err := match.Find(msg.ID)
+
+			if err != nil {
+				t.Fatalf("Expected the next game to be created, got %v", err)
+			}
+
+			return m
+
+		}
+	}
+
+	t.Fatal("Expected the players to be sent to the next game")
+	return nil
+
+}
+
+// asked returns true if the recorder was asked to choose who goes first
+func asked(r *recorder) bool {
+
+	for _, msg := range r.Messages() {
+		if msg, ok := msg.(server.Message); ok && msg.Header == "choose_first" {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+func TestSeries(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	// the lobby is not running, the match list updates are dropped
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	series := match.NewSeries(3)
+
+	m := match.New("series", "p1", false)
+	defer m.Dispose()
+
+	m.SetSeries(series)
+
+	r1, r2 := startMatch(m)
+
+	m.Do(func() {
+		m.Concede(m.Player1)
+		m.ChooseFirst(m.Player2, true)
+	})
+
+	if result := gameOver(r1); result == nil || result.Rematch {
+		t.Fatalf("Expected the game to end without a rematch, got %+v", result)
+	}
+
+	if series.Wins("p2") != 1 || series.Wins("p1") != 0 || series.Winner() != "" {
+		t.Fatalf("Expected p2 to lead the series 1-0, got %v-%v", series.Wins("p2"), series.Wins("p1"))
+	}
+
+	if !asked(r1) || asked(r2) {
+		t.Error("Expected only the loser of the game to choose who goes first")
+	}
+
+	if !warned(r2, "can't choose") {
+		t.Error("Expected the winner of the game not to be able to choose who goes first")
+	}
+
+	m.Do(func() { m.ChooseFirst(m.Player1, false) })
+
+	next := nextGame(t, r2)
+	defer next.Dispose()
+
+	if next.FirstPlayer() != "p2" {
+		t.Errorf("Expected p2 to go first as chosen by p1, %s does", next.FirstPlayer())
+	}
+
+	r1, _ = startMatch(next)
+
+	next.Do(func() { next.Concede(next.Player1) })
+
+	if series.Winner() != "p2" || series.Wins("p2") != 2 {
+		t.Fatalf("Expected p2 to win the series 2-0, got %v-%v", series.Wins("p2"), series.Wins("p1"))
+	}
+
+	if asked(r1) {
+		t.Error("Expected no further game once the series was decided")
+	}
+
+	var score *server.SeriesMessage
+
+	for _, msg := range r1.Messages() {
+		if msg, ok := msg.(server.SeriesMessage); ok {
+			score = &msg
+		}
+	}
+
+	if score == nil || score.Winner != "p2" || score.Games != 3 {
+		t.Errorf("Expected the players to be sent the final score of the series, got %+v", score)
+	}
+
+}
+
+func TestSeriesDraw(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	series := match.NewSeries(3)
+
+	m := match.New("series", "p1", false)
+	defer m.Dispose()
+
+	m.SetSeries(series)
+
+	r1, r2 := startMatch(m)
+
+	second := r2
+
+	if m.FirstPlayer() == "p2" {
+		second = r1
+	}
+
+	m.Do(func() {
+		m.OfferDraw(m.Player1)
+		m.AcceptDraw(m.Player2)
+	})
+
+	if series.Wins("p1") != 0 || series.Wins("p2") != 0 {
+		t.Error("Expected a draw not to count towards the series")
+	}
+
+	if !asked(second) {
+		t.Error("Expected the player who went second to choose who goes first after a draw")
+	}
+
+}
+
+// endSeriesWithout concedes the first game of a series by p2 after the players with the uids
+// left, and returns the last score sent to p1 and the saved result of the game
+func endSeriesWithout(t *testing.T, left ...string) (*server.SeriesMessage, db.Match) {
+
+	matches := &matchStore{}
+
+	storage := db.NewMemoryStorage()
+	storage.Matches = matches
+
+	db.Use(storage)
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	m := match.New("series", "p1", false)
+	defer m.Dispose()
+
+	m.SetSeries(match.NewSeries(3))
+
+	r1, _ := startMatch(m)
+
+	m.Do(func() {
+
+		for _, p := range []*match.PlayerReference{m.Player1, m.Player2} {
+			for _, uid := range left {
+				if p.UID == uid {
+					p.Controller = nil
+				}
+			}
+		}
+
+		m.Concede(m.Player2)
+
+	})
+
+	var score *server.SeriesMessage
+
+	for _, msg := range r1.Messages() {
+		if msg, ok := msg.(server.SeriesMessage); ok {
+			score = &msg
+		}
+	}
+
+	if len(matches.saved) != 1 {
+		t.Fatalf("Expected the result of the game to be saved, got %v results", len(matches.saved))
+	}
+
+	return score, matches.saved[0]
+
+}
+
+func TestSeriesForfeit(t *testing.T) {
+
+	// p2 left and conceded, p1 is 1-0 up and stayed
+	score, result := endSeriesWithout(t, "p2")
+
+	if score == nil || score.Winner != "p1" || score.Reason != match.Forfeit {
+		t.Errorf("Expected p1 to win the series by forfeit, got %+v", score)
+	}
+
+	if s := result.SeriesResult; s == nil || s.Winner != "p1" || s.Reason != match.Forfeit || s.Wins["p1"] != 1 {
+		t.Errorf("Expected the forfeited series to be saved with the game, got %+v", s)
+	}
+
+}
+
+func TestSeriesAbandoned(t *testing.T) {
+
+	_, result := endSeriesWithout(t, "p1", "p2")
+
+	if s := result.SeriesResult; s == nil || s.Winner != "" || s.Reason != match.SeriesAbandoned {
+		t.Errorf("Expected the abandoned series to be saved with the game, got %+v", s)
+	}
+
+}
@@ -0,0 +1,235 @@
+package game
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/metrics"
+	"duel-masters/server"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// queueInterval is how often the matchmaker tries to pair the queued users
+	queueInterval = 2 * time.Second
+	// queueBaseRange is the rating difference that is always accepted
+	queueBaseRange = 100
+	// queueRangeGrowth is how much the accepted rating difference grows for every second a user waits
+	queueRangeGrowth = 10
+)
+
+// queueEntry is a user waiting in the matchmaking queue
+type queueEntry struct {
+	socket *server.Socket
+	rating int
+	joined time.Time
+}
+
+// ratingRange returns the rating difference the user accepts after waiting until now
+func (e *queueEntry) ratingRange(now time.Time) int {
+	return queueBaseRange + int(now.Sub(e.joined)/time.Second)*queueRangeGrowth
+}
+
+var queue = make([]*queueEntry, 0)
+var queueMutex = &sync.Mutex{}
+
+// joinQueue adds the socket's user to the matchmaking queue, a user that is
+// already queued from another connection is moved to this one. Users that are
+// playing a match can't join the queue
+func joinQueue(s *server.Socket) {
+
+	if match.InMatch(s.User.UID) {
+		s.Send(server.WarningMessage{
+			Header:  "warn",
+			Message: "You can't look for an opponent while you are playing a match",
+		})
+		return
+	}
+
+	// the user of the socket is loaded when it connects, the rating has
+	// changed since if the user played matches in the meantime
+	user, err := db.Users().Get(s.User.UID)
+
+	if err != nil {
+		logrus.Errorf("Failed to load %s to join the queue. %v", s.User.Username, err)
+		s.Send(server.WarningMessage{
+			Header:  "warn",
+			Message: "Failed to join the queue, please try again",
+		})
+		return
+	}
+
+	queueMutex.Lock()
+
+	entry := &queueEntry{
+		socket: s,
+		rating: user.EffectiveRating(),
+		joined: time.Now(),
+	}
+
+	for i, e := range queue {
+		if e.socket.User.UID == s.User.UID {
+			entry.joined = e.joined
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+
+	queue = append(queue, entry)
+	waiting := len(queue)
+
+	queueMutex.Unlock()
+
+	s.Send(server.QueueMessage{
+		Header:  "queue",
+		Queued:  true,
+		Waiting: waiting,
+	})
+
+}
+
+// leaveQueue removes the socket from the matchmaking queue
+func leaveQueue(s *server.Socket) {
+
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	for i, e := range queue {
+		if e.socket == s {
+			queue = append(queue[:i], queue[i+1:]...)
+			return
+		}
+	}
+
+}
+
+// pairQueue returns the pairs of users that can be matched, the users that have waited the
+// longest are paired first with the user closest to their rating. Two users are paired when
+// their rating difference is within the range of either of them. The paired users are
+// removed from the queue
+func pairQueue(now time.Time) [][2]*queueEntry {
+
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].joined.Before(queue[j].joined)
+	})
+
+	pairs := make([][2]*queueEntry, 0)
+	paired := make(map[*queueEntry]bool)
+
+	for i, a := range queue {
+
+		if paired[a] {
+			continue
+		}
+
+		var best *queueEntry
+		bestDiff := 0
+
+		for _, b := range queue[i+1:] {
+
+			if paired[b] || a.socket.User.UID == b.socket.User.UID {
+				continue
+			}
+
+			diff := a.rating - b.rating
+			if diff < 0 {
+				diff = -diff
+			}
+
+			if diff > a.ratingRange(now) && diff > b.ratingRange(now) {
+				continue
+			}
+
+			if best == nil || diff < bestDiff {
+				best = b
+				bestDiff = diff
+			}
+
+		}
+
+		if best != nil {
+			paired[a] = true
+			paired[best] = true
+			pairs = append(pairs, [2]*queueEntry{a, best})
+		}
+
+	}
+
+	remaining := make([]*queueEntry, 0)
+
+	for _, e := range queue {
+		if !paired[e] {
+			remaining = append(remaining, e)
+		}
+	}
+
+	queue = remaining
+
+	return pairs
+
+}
+
+// Matchmaker pairs the users in the matchmaking queue, creates their match
+// and lets them know which match to join
+func Matchmaker() {
+
+	ticker := time.NewTicker(queueInterval)
+
+	defer ticker.Stop()
+
+	for range ticker.C {
+
+		for _, pair := range pairQueue(time.Now()) {
+			startQueuedMatch(pair[0].socket, pair[1].socket)
+		}
+
+	}
+
+}
+
+// startQueuedMatch creates a hidden match that can only be joined by the two users,
+// it is closed if they don't both join it in time. If one of them started playing
+// another match while waiting, no match is created and the other is queued again
+func startQueuedMatch(p1 *server.Socket, p2 *server.Socket) *match.Match {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered from starting a queued match. %v", r)
+			metrics.Panics.Inc("queue_start_match")
+		}
+	}()
+
+	if match.InMatch(p1.User.UID) || match.InMatch(p2.User.UID) {
+
+		for _, s := range []*server.Socket{p1, p2} {
+			if !match.InMatch(s.User.UID) {
+				joinQueue(s)
+			}
+		}
+
+		return nil
+
+	}
+
+	m := match.New(fmt.Sprintf("%s vs %s", p1.User.Username, p2.User.Username), p1.User.UID, false)
+	m.Do(func() { m.Reserve(p2.User.UID) })
+
+	logrus.Debugf("Paired %s and %s in match %s", p1.User.Username, p2.User.Username, m.ID)
+
+	for _, s := range []*server.Socket{p1, p2} {
+		s.Send(server.MatchFoundMessage{
+			Header: "match_found",
+			ID:     m.ID,
+		})
+	}
+
+	return m
+
+}
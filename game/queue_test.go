@@ -0,0 +1,135 @@
+package game
+
+import (
+	"duel-masters/db"
+	"duel-masters/game/match"
+	"duel-masters/server"
+	"testing"
+	"time"
+)
+
+func queued(uid string, rating int, joined time.Time) *server.Socket {
+
+	s := &server.Socket{User: db.User{UID: uid, Username: uid, Rating: rating}}
+
+	queue = append(queue, &queueEntry{
+		socket: s,
+		rating: s.User.EffectiveRating(),
+		joined: joined,
+	})
+
+	return s
+
+}
+
+func TestPairQueue(t *testing.T) {
+
+	now := time.Now()
+
+	queue = make([]*queueEntry, 0)
+
+	a := queued("a", 1200, now.Add(-5*time.Second))
+	b := queued("b", 1900, now.Add(-4*time.Second))
+	c := queued("c", 1250, now.Add(-3*time.Second))
+	d := queued("d", 1230, now.Add(-2*time.Second))
+
+	pairs := pairQueue(now)
+
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 pair, got %v", len(pairs))
+	}
+
+	// a waited the longest and d is closest to their rating
+	if pairs[0][0].socket != a || pairs[0][1].socket != d {
+		t.Errorf("Expected a to be paired with d, got %s and %s", pairs[0][0].socket.User.UID, pairs[0][1].socket.User.UID)
+	}
+
+	if len(queue) != 2 || queue[0].socket != b || queue[1].socket != c {
+		t.Errorf("Expected b and c to stay in the queue")
+	}
+
+	// the accepted rating difference grows while the users wait
+	if pairs := pairQueue(now.Add(time.Minute)); len(pairs) != 1 {
+		t.Errorf("Expected b and c to be paired after waiting, got %v pairs", len(pairs))
+	}
+
+	if len(queue) != 0 {
+		t.Errorf("Expected the queue to be empty, %v users are waiting", len(queue))
+	}
+
+}
+
+func TestQueuedMatch(t *testing.T) {
+
+	go func() {
+		for range match.LobbyMatchList() {
+		}
+	}()
+
+	db.Use(db.NewMemoryStorage())
+
+	queue = make([]*queueEntry, 0)
+
+	sockets := make([]*server.Socket, 0)
+
+	for _, uid := range []string{"a", "b", "c"} {
+		user := db.User{UID: uid, Username: uid}
+		db.Users().Create(user)
+		sockets = append(sockets, &server.Socket{User: user})
+	}
+
+	a, b, c := sockets[0], sockets[1], sockets[2]
+
+	m := startQueuedMatch(a, b)
+	defer m.Dispose()
+
+	if m.Visible {
+		t.Error("Expected the match of the queue to be hidden from the list of matches")
+	}
+
+	// a started playing the match before being paired with c
+	if other := startQueuedMatch(a, c); other != nil {
+		other.Dispose()
+		t.Error("Expected no match to be created for a user who is playing a match")
+	}
+
+	if len(queue) != 1 || queue[0].socket != c {
+		t.Errorf("Expected c to be queued again, %v users are waiting", len(queue))
+	}
+
+	queue = make([]*queueEntry, 0)
+
+	// the users are playing the match until it is closed
+	joinQueue(a)
+
+	if len(queue) != 0 {
+		t.Errorf("Expected users who are playing a match not to be queued, %v users are waiting", len(queue))
+	}
+
+	m.Dispose()
+
+	joinQueue(a)
+
+	if len(queue) != 1 {
+		t.Errorf("Expected the user to be queued once the match was closed, %v users are waiting", len(queue))
+	}
+
+}
+
+func TestJoinQueueWithCurrentRating(t *testing.T) {
+
+	db.Use(db.NewMemoryStorage())
+
+	queue = make([]*queueEntry, 0)
+
+	// the rating changed after the socket connected
+	s := &server.Socket{User: db.User{UID: "a", Username: "a", Rating: 1200}}
+	db.Users().Create(db.User{UID: "a", Username: "a", Rating: 1350})
+
+	joinQueue(s)
+
+	if len(queue) != 1 || queue[0].rating != 1350 {
+		t.Errorf("Expected the user to be queued with the current rating of 1350, got %+v", queue)
+	}
+
+}
@@ -0,0 +1,287 @@
+package game
+
+import (
+	"duel-masters/db"
+	"duel-masters/metrics"
+	"duel-masters/server"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	minReplayDelay = 250 * time.Millisecond
+	maxReplayDelay = 2 * time.Second
+)
+
+// ReplayHub is used to create a Hub that plays back a recorded match to a socket.
+// The playback is divided into steps, a step is a recorded state update together
+// with the chat messages that were sent before it
+type ReplayHub struct {
+	replay  db.Replay
+	steps   [][]db.ReplayEvent
+	step    int
+	playing bool
+	stop    chan bool
+	mutex   sync.Mutex
+}
+
+// LoadReplay returns a replay hub for the match with the specified id
+func LoadReplay(id string) (*ReplayHub, error) {
+
+	replay, err := db.GetReplay(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([][]db.ReplayEvent, 0)
+	pending := make([]db.ReplayEvent, 0)
+
+	for _, event := range replay.Events {
+
+		switch event.Header {
+
+		case "chat":
+			// chat messages sent by players are also recorded, only show the ones sent by the match
+			if event.Player == "" {
+				pending = append(pending, event)
+			}
+
+		case "state_update":
+			steps = append(steps, append(pending, event))
+			pending = make([]db.ReplayEvent, 0)
+
+		}
+
+	}
+
+	// show the final chat messages, such as the winner of the match, with the last state
+	if len(steps) > 0 {
+		steps[len(steps)-1] = append(steps[len(steps)-1], pending...)
+	} else {
+		steps = append(steps, pending)
+	}
+
+	return &ReplayHub{
+		replay: replay,
+		steps:  steps,
+	}, nil
+
+}
+
+// Name just returns "replay", obligatory for a hub
+func (r *ReplayHub) Name() string {
+	return server.ReplayHub
+}
+
+// Parse handles websocket messages in this Hub
+func (r *ReplayHub) Parse(s *server.Socket, request *server.Request) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Warnf("Recovered after parsing message in replay. %v", r)
+			metrics.Panics.Inc("replay_parse")
+		}
+	}()
+
+	switch request.Header {
+
+	case "join_match", "join_replay":
+		{
+			r.show(s, 0, true)
+		}
+
+	case "replay_next":
+		{
+			r.pause()
+			r.show(s, r.currentStep()+1, true)
+		}
+
+	case "replay_previous":
+		{
+			r.pause()
+			r.show(s, r.currentStep()-1, false)
+		}
+
+	case "replay_goto":
+		{
+
+			msg := request.Message.(*server.ReplayGotoRequest)
+
+			r.pause()
+			r.show(s, msg.Step, false)
+
+		}
+
+	case "replay_play":
+		{
+			r.play(s)
+		}
+
+	case "replay_pause":
+		{
+			r.pause()
+			s.Send(r.info())
+		}
+
+	}
+
+}
+
+// OnSocketClose is called when a socket disconnects
+func (r *ReplayHub) OnSocketClose(s *server.Socket) {
+	r.pause()
+}
+
+func (r *ReplayHub) currentStep() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.step
+}
+
+// show sends the specified step to the socket, chat messages are only
+// sent when moving forward to avoid showing them multiple times
+func (r *ReplayHub) show(s *server.Socket, step int, withChat bool) {
+
+	r.mutex.Lock()
+
+	if step < 0 {
+		step = 0
+	}
+
+	if step >= len(r.steps) {
+		step = len(r.steps) - 1
+	}
+
+	r.step = step
+	events := r.steps[step]
+
+	r.mutex.Unlock()
+
+	for _, event := range events {
+
+		if event.Header == "chat" && !withChat {
+			continue
+		}
+
+		s.Send(event.Data)
+
+	}
+
+	s.Send(r.info())
+
+}
+
+// play shows the remaining steps one after another, with the recorded delay between them
+func (r *ReplayHub) play(s *server.Socket) {
+
+	r.mutex.Lock()
+
+	if r.playing {
+		r.mutex.Unlock()
+		return
+	}
+
+	r.playing = true
+	stop := make(chan bool)
+	r.stop = stop
+
+	r.mutex.Unlock()
+
+	s.Send(r.info())
+
+	go func() {
+
+		defer func() {
+			if r := recover(); r != nil {
+				logrus.Warnf("Recovered from replay playback. %v", r)
+				metrics.Panics.Inc("replay_playback")
+			}
+		}()
+
+		for {
+
+			step := r.currentStep()
+
+			if step >= len(r.steps)-1 {
+				r.pause()
+				s.Send(r.info())
+				return
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(r.delay(step)):
+				r.show(s, step+1, true)
+			}
+
+		}
+
+	}()
+
+}
+
+// pause stops the playback if the replay is playing
+func (r *ReplayHub) pause() {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.playing {
+		return
+	}
+
+	r.playing = false
+	close(r.stop)
+
+}
+
+// delay returns the time that passed between the specified step and the next one in the match
+func (r *ReplayHub) delay(step int) time.Duration {
+
+	current := r.steps[step]
+	next := r.steps[step+1]
+
+	if len(current) < 1 || len(next) < 1 {
+		return minReplayDelay
+	}
+
+	delay := time.Duration(next[len(next)-1].Time-current[len(current)-1].Time) * time.Millisecond
+
+	if delay < minReplayDelay {
+		return minReplayDelay
+	}
+
+	if delay > maxReplayDelay {
+		return maxReplayDelay
+	}
+
+	return delay
+
+}
+
+func (r *ReplayHub) info() server.ReplayMessage {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	players := make([]string, 0)
+
+	for _, p := range r.replay.Players {
+		players = append(players, p.Username)
+	}
+
+	return server.ReplayMessage{
+		Header:  "replay",
+		ID:      r.replay.UID,
+		Name:    r.replay.Name,
+		Players: players,
+		Steps:   len(r.steps),
+		Step:    r.step,
+		Playing: r.playing,
+	}
+
+}
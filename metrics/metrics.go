@@ -0,0 +1,207 @@
+// Package metrics collects counters and gauges of the server and writes them
+// in the Prometheus text exposition format
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// collector is a metric that can be written in the text format
+type collector interface {
+	write(w io.Writer)
+}
+
+var registry = make([]collector, 0)
+var registryMutex = sync.Mutex{}
+
+func register(c collector) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	registry = append(registry, c)
+}
+
+// Write writes every registered metric in the Prometheus text format
+func Write(w io.Writer) {
+
+	registryMutex.Lock()
+	collectors := append([]collector{}, registry...)
+	registryMutex.Unlock()
+
+	for _, c := range collectors {
+		c.write(w)
+	}
+
+}
+
+// Counter is a value that only goes up, optionally split by the value of a label
+type Counter struct {
+	name   string
+	help   string
+	label  string
+	values map[string]float64
+	mutex  sync.Mutex
+}
+
+// NewCounter registers a new counter. If label is not empty the counter keeps a separate
+// value for each value of the label
+func NewCounter(name string, help string, label string) *Counter {
+
+	c := &Counter{
+		name:   name,
+		help:   help,
+		label:  label,
+		values: make(map[string]float64),
+	}
+
+	register(c)
+
+	return c
+
+}
+
+// Inc increases the counter for the label value by 1
+func (c *Counter) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+// Add increases the counter for the label value
+func (c *Counter) Add(labelValue string, v float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[labelValue] += v
+}
+
+func (c *Counter) write(w io.Writer) {
+
+	c.mutex.Lock()
+	values := make(map[string]float64)
+	for k, v := range c.values {
+		values[k] = v
+	}
+	c.mutex.Unlock()
+
+	writeFamily(w, c.name, c.help, "counter", c.label, values)
+
+}
+
+// Summary tracks the number and sum of observed values, such as durations.
+// The average is the sum divided by the count
+type Summary struct {
+	name  string
+	help  string
+	sum   float64
+	count uint64
+	mutex sync.Mutex
+}
+
+// NewSummary registers a new summary
+func NewSummary(name string, help string) *Summary {
+
+	s := &Summary{
+		name: name,
+		help: help,
+	}
+
+	register(s)
+
+	return s
+
+}
+
+// Observe adds a value to the summary
+func (s *Summary) Observe(v float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sum += v
+	s.count++
+}
+
+func (s *Summary) write(w io.Writer) {
+
+	s.mutex.Lock()
+	sum, count := s.sum, s.count
+	s.mutex.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", s.name, escapeHelp(s.help))
+	fmt.Fprintf(w, "# TYPE %s summary\n", s.name)
+	fmt.Fprintf(w, "%s_sum %s\n", s.name, formatValue(sum))
+	fmt.Fprintf(w, "%s_count %v\n", s.name, count)
+
+}
+
+// GaugeFunc is a value that is calculated whenever the metrics are written
+type GaugeFunc struct {
+	name  string
+	help  string
+	label string
+	fn    func() map[string]float64
+}
+
+// NewGaugeFunc registers a gauge whose values are returned by fn, keyed by the value of
+// the label. Without a label fn returns a single value with an empty key
+func NewGaugeFunc(name string, help string, label string, fn func() map[string]float64) *GaugeFunc {
+
+	g := &GaugeFunc{
+		name:  name,
+		help:  help,
+		label: label,
+		fn:    fn,
+	}
+
+	register(g)
+
+	return g
+
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	writeFamily(w, g.name, g.help, "gauge", g.label, g.fn())
+}
+
+// writeFamily writes a metric with one sample per label value, ordered by the label value
+func writeFamily(w io.Writer, name string, help string, kind string, label string, values map[string]float64) {
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+
+	if label == "" {
+		fmt.Fprintf(w, "%s %s\n", name, formatValue(values[""]))
+		return
+	}
+
+	keys := make([]string, 0)
+
+	for k := range values {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{%s=\"%s\"} %s\n", name, label, escapeLabel(k), formatValue(values[k]))
+	}
+
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
+
+// Panics counts the panics that were recovered, by where they happened
+var Panics = NewCounter("duelmasters_recovered_panics_total", "Panics that were recovered, by where they happened", "source")
@@ -0,0 +1,43 @@
+package metrics_test
+
+import (
+	"bytes"
+	"duel-masters/metrics"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+
+	counter := metrics.NewCounter("test_plays_total", "Cards that were played", "card")
+	counter.Inc(`Bolshack "Dragon"`)
+	counter.Add("Aqua Hulcus", 2)
+	counter.Inc("Aqua Hulcus")
+
+	summary := metrics.NewSummary("test_duration_seconds", "Duration of\nthe matches")
+	summary.Observe(30)
+	summary.Observe(90)
+
+	metrics.NewGaugeFunc("test_matches", "Open matches", "", func() map[string]float64 {
+		return map[string]float64{"": 4}
+	})
+
+	var b bytes.Buffer
+
+	metrics.Write(&b)
+
+	expected := []string{
+		"# HELP test_plays_total Cards that were played\n# TYPE test_plays_total counter\n",
+		"test_plays_total{card=\"Aqua Hulcus\"} 3\ntest_plays_total{card=\"Bolshack \\\"Dragon\\\"\"} 1\n",
+		"# HELP test_duration_seconds Duration of\\nthe matches\n# TYPE test_duration_seconds summary\n",
+		"test_duration_seconds_sum 120\ntest_duration_seconds_count 2\n",
+		"# TYPE test_matches gauge\ntest_matches 4\n",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(b.String(), e) {
+			t.Errorf("Expected the metrics to contain %q, got:\n%s", e, b.String())
+		}
+	}
+
+}
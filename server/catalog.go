@@ -0,0 +1,83 @@
+package server
+
+// The catalog of every message in the protocol. New messages must be registered
+// here with the protocol version they were added in, and outbound messages of a
+// newer version may only be sent to sockets that negotiated it.
+//
+// Version 2 adds bot_state
+// Version 3 adds concede, draw offers, rematches, series and requeue
+func init() {
+
+	lobby := []string{LobbyHub}
+	match := []string{MatchHub}
+	replay := []string{ReplayHub}
+	games := []string{MatchHub, ReplayHub}
+
+	// Client -> server
+
+	registerInbound("hello", 1, AllHubs, HelloRequest{}, "Negotiates the protocol version, answered with version or protocol_error")
+	registerInbound("mpong", 1, match, Message{}, "Answers an mping, keeps the connection to the match alive")
+	registerInbound("subscribe", 1, lobby, Message{}, "Subscribes to the chat, user list and match list of the lobby")
+	registerInbound("chat", 1, []string{LobbyHub, MatchHub}, ChatRequest{}, "Sends a chat message")
+	registerInbound("queue", 1, lobby, Message{}, "Joins the matchmaking queue")
+	registerInbound("leave_queue", 1, lobby, Message{}, "Leaves the matchmaking queue")
+	registerInbound("join_match", 1, games, Message{}, "Joins or reconnects to the match as a player or spectator, starts the playback of a replay")
+	registerInbound("choose_deck", 1, match, ChooseDeckRequest{}, "Chooses the deck to play the match with")
+	registerInbound("add_to_manazone", 1, match, CardRequest{}, "Puts a card from the hand into the manazone")
+	registerInbound("add_to_playzone", 1, match, CardRequest{}, "Plays a card from the hand")
+	registerInbound("attack_player", 1, match, CardRequest{}, "Attacks the opponent with a creature")
+	registerInbound("attack_creature", 1, match, CardRequest{}, "Attacks a creature of the opponent")
+	registerInbound("end_turn", 1, match, Message{}, "Ends the turn")
+	registerInbound("concede", 3, match, Message{}, "Concedes the game, the opponent wins")
+	registerInbound("offer_draw", 3, match, Message{}, "Offers the opponent a draw until the end of the turn")
+	registerInbound("accept_draw", 3, match, Message{}, "Accepts the draw the opponent offered, the game ends in a draw")
+	registerInbound("rematch", 3, match, Message{}, "Asks for a rematch after the game ended, it starts once both players asked for it")
+	registerInbound("choose_first", 3, match, ChooseFirstRequest{}, "Chooses who takes the first turn of the next game of a series, sent by the loser of the previous game")
+	registerInbound("action", 1, match, ActionRequest{}, "Answers the current action prompt, it is dropped if no prompt is pending")
+	registerInbound("join_replay", 1, replay, Message{}, "Starts the playback of a replay")
+	registerInbound("replay_next", 1, replay, Message{}, "Shows the next step of the replay")
+	registerInbound("replay_previous", 1, replay, Message{}, "Shows the previous step of the replay")
+	registerInbound("replay_goto", 1, replay, ReplayGotoRequest{}, "Shows the specified step of the replay")
+	registerInbound("replay_play", 1, replay, Message{}, "Plays the remaining steps of the replay")
+	registerInbound("replay_pause", 1, replay, Message{}, "Pauses the playback of the replay")
+
+	// Server -> client
+
+	registerOutbound("hello", 1, AllHubs, HelloMessage{}, "Sent once the connection is authorized, lists the supported protocol versions")
+	registerOutbound("version", 1, AllHubs, VersionMessage{}, "The protocol version negotiated by hello")
+	registerOutbound("protocol_error", 1, AllHubs, ProtocolErrorMessage{}, "A message from the client was not understood or is invalid")
+	registerOutbound("error", 1, match, WarningMessage{}, "An error such as a rejected connection, also announces the winner when the match ends")
+	registerOutbound("warn", 1, match, WarningMessage{}, "A warning to show to the player, e.g. when a move is rejected because a prompt has to be answered first")
+	registerOutbound("mping", 1, match, Message{}, "Must be answered with mpong")
+	registerOutbound("chat", 1, lobby, LobbyChatMessages{}, "New chat messages of the lobby")
+	registerOutbound("chat", 1, games, ChatMessage{}, "A chat message of the match")
+	registerOutbound("pinned_messages", 1, lobby, PinnedMessages{}, "Messages pinned to the top of the lobby chat")
+	registerOutbound("users", 1, lobby, UserListMessage{}, "The users that are online")
+	registerOutbound("matches", 1, lobby, MatchesListMessage{}, "The open and running matches")
+	registerOutbound("queue", 1, lobby, QueueMessage{}, "The matchmaking queue status of the user")
+	registerOutbound("match_found", 1, lobby, MatchFoundMessage{}, "The matchmaking queue found an opponent, the match can be joined")
+	registerOutbound("choose_deck", 1, match, DecksMessage{}, "Prompts the player to choose a deck")
+	registerOutbound("deck_rejected", 1, match, WarningMessage{}, "The chosen deck is not legal, another one must be chosen")
+	registerOutbound("state_update", 1, games, MatchStateMessage{}, "The state of the match as seen by the user")
+	registerOutbound("bot_state", 2, match, BotStateMessage{}, "Sent to bots instead of state_update, with the moves the bot can make and the constraints of its pending prompt")
+	registerOutbound("action", 1, match, ActionMessage{}, "Prompts the player to select cards, answered with action")
+	registerOutbound("action", 1, match, MultipartActionMessage{}, "Prompts the player to select cards from several named groups, answered with action")
+	registerOutbound("action_error", 1, match, ActionWarningMessage{}, "The selection did not meet the requirements of the prompt")
+	registerOutbound("close_action", 1, match, Message{}, "Closes the current action prompt")
+	registerOutbound("wait", 1, match, WaitMessage{}, "The opponent is making a choice")
+	registerOutbound("end_wait", 1, match, Message{}, "The opponent made their choice")
+	registerOutbound("show_cards", 1, match, ShowCardsMessage{}, "Shows cards to the player without a prompt")
+	registerOutbound("opponent_disconnected", 1, match, Message{}, "The opponent lost their connection")
+	registerOutbound("opponent_reconnected", 1, match, Message{}, "The opponent reconnected")
+	registerOutbound("reconnect_countdown", 1, match, ReconnectCountdownMessage{}, "Seconds left for a disconnected player to reconnect")
+	registerOutbound("game_over", 3, match, GameOverMessage{}, "The game ended, sent after the error message that announces the result")
+	registerOutbound("draw_offered", 3, match, Message{}, "The opponent offered a draw, answered with accept_draw")
+	registerOutbound("rematch_offered", 3, match, Message{}, "The opponent asked for a rematch, answered with rematch")
+	registerOutbound("rematch", 3, match, MatchFoundMessage{}, "Both players asked for a rematch, the new match can be joined")
+	registerOutbound("requeue", 3, match, Message{}, "The opponent found by the matchmaking queue did not join the match, the user can queue again")
+	registerOutbound("series", 3, match, SeriesMessage{}, "The score of the series the match is part of, sent when a game starts and ends")
+	registerOutbound("choose_first", 3, match, Message{}, "Prompts the loser of a game in a series to choose who takes the first turn of the next game, answered with choose_first")
+	registerOutbound("next_game", 3, match, MatchFoundMessage{}, "The next game of the series was created and can be joined")
+	registerOutbound("replay", 1, replay, ReplayMessage{}, "Information about the replay and the shown step")
+
+}
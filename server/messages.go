@@ -11,6 +11,8 @@ type Message struct {
 type DecksMessage struct {
 	Header string    `json:"header"`
 	Decks  []db.Deck `json:"decks"`
+	// Previous is the uid of the deck the player used in the previous game of a rematch
+	Previous string `json:"previous,omitempty"`
 }
 
 // ChatMessage stores information about a chat message
@@ -23,12 +25,13 @@ type ChatMessage struct {
 
 // CardState stores information about the state of a card
 type CardState struct {
-	CardID      string `json:"virtualId"`
-	ImageID     string `json:"uid"`
-	Name        string `json:"name"`
-	Civ         string `json:"civilization"`
-	Tapped      bool   `json:"tapped"`
-	CanBePlayed bool   `json:"canBePlayed"`
+	CardID      string   `json:"virtualId"`
+	ImageID     string   `json:"uid"`
+	Name        string   `json:"name"`
+	Civ         string   `json:"civilization"`
+	Civs        []string `json:"civilizations"`
+	Tapped      bool     `json:"tapped"`
+	CanBePlayed bool     `json:"canBePlayed"`
 }
 
 // PlayerState stores information about the state of the current player
@@ -51,6 +54,14 @@ type MatchState struct {
 	Me           PlayerState `json:"me"`
 	Opponent     PlayerState `json:"opponent"`
 	Spectator    bool        `json:"spectator"`
+	Clock        *ClockState `json:"clock,omitempty"`
+}
+
+// ClockState holds the remaining time in seconds of a match with time limits, -1 if there is no limit
+type ClockState struct {
+	Turn     int `json:"turn"`
+	Me       int `json:"me"`
+	Opponent int `json:"opponent"`
 }
 
 // MatchStateMessage is the message that should be sent to the client for state updates
@@ -73,6 +84,7 @@ type ActionMessage struct {
 	MinSelections int         `json:"minSelections"`
 	MaxSelections int         `json:"maxSelections"`
 	Cancellable   bool        `json:"cancellable"`
+	Suggested     []string    `json:"suggested,omitempty"`
 }
 
 // MultipartActionMessage is used to prompt the user to make a selection of the specified cards
@@ -117,6 +129,7 @@ type UserMessage struct {
 	Color       string   `json:"color"`
 	Hub         string   `json:"hub"`
 	Permissions []string `json:"permissions"`
+	Bot         bool     `json:"bot"`
 }
 
 // UserListMessage is used to send a list of online users
@@ -153,3 +166,114 @@ type PinnedMessages struct {
 	Header   string   `json:"header"`
 	Messages []string `json:"messages"`
 }
+
+// ReplayMessage holds information about a replay and the current step of its playback
+type ReplayMessage struct {
+	Header  string   `json:"header"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Players []string `json:"players"`
+	Steps   int      `json:"steps"`
+	Step    int      `json:"step"`
+	Playing bool     `json:"playing"`
+}
+
+// ReconnectCountdownMessage is used to tell how many seconds a disconnected player has left to reconnect
+type ReconnectCountdownMessage struct {
+	Header   string `json:"header"`
+	Username string `json:"username"`
+	Seconds  int    `json:"seconds"`
+}
+
+// QueueMessage tells the user if they are in the matchmaking queue and how many users are waiting in it
+type QueueMessage struct {
+	Header  string `json:"header"`
+	Queued  bool   `json:"queued"`
+	Waiting int    `json:"waiting"`
+}
+
+// MatchFoundMessage is sent to both users paired by the matchmaking queue
+type MatchFoundMessage struct {
+	Header string `json:"header"`
+	ID     string `json:"id"`
+}
+
+// GameOverMessage is sent to the players and spectators when the game ended
+type GameOverMessage struct {
+	Header string `json:"header"`
+	// Winner is the username of the winner, it is empty if the game ended in a draw
+	Winner string `json:"winner,omitempty"`
+	Reason string `json:"reason"`
+	// Rematch is true if the players can ask for a rematch
+	Rematch bool `json:"rematch"`
+}
+
+// SeriesMessage is the score of a best-of-N series of matches
+type SeriesMessage struct {
+	Header  string              `json:"header"`
+	Games   int                 `json:"games"`
+	Players []SeriesPlayerState `json:"players"`
+	// Winner is the username of the player who won the series, it is empty while the series is running
+	Winner string `json:"winner,omitempty"`
+	// Reason is "forfeit" or "abandoned" if the series ended because a player left
+	Reason string `json:"reason,omitempty"`
+}
+
+// SeriesPlayerState is a player of a series and the number of games they won
+type SeriesPlayerState struct {
+	Username string `json:"username"`
+	Wins     int    `json:"wins"`
+}
+
+// HelloMessage is sent once the socket is authorized and lists the protocol versions the server speaks
+type HelloMessage struct {
+	Header   string `json:"header"`
+	Versions []int  `json:"versions"`
+}
+
+// VersionMessage tells the client which protocol version was negotiated
+type VersionMessage struct {
+	Header  string `json:"header"`
+	Version int    `json:"version"`
+}
+
+// ProtocolErrorMessage is sent when a message from the client could not be decoded or is invalid
+type ProtocolErrorMessage struct {
+	Header  string `json:"header"`
+	Request string `json:"request"`
+	Message string `json:"message"`
+}
+
+// BotStateMessage is sent to bots instead of MatchStateMessage, it also lists the moves
+// the bot can make and the constraints of the prompt it has to respond to
+type BotStateMessage struct {
+	Header string            `json:"header"`
+	State  MatchState        `json:"state"`
+	Legal  LegalActionsState `json:"legal"`
+	Prompt *PromptState      `json:"prompt,omitempty"`
+}
+
+// LegalActionsState lists the moves a player can make, it is empty while the player has to respond to a prompt
+type LegalActionsState struct {
+	Charge    []string        `json:"charge"`
+	Play      []string        `json:"play"`
+	Attackers []AttackerState `json:"attackers"`
+	EndTurn   bool            `json:"endTurn"`
+}
+
+// AttackerState is a creature that can attack and what it can attack
+type AttackerState struct {
+	CardID    string   `json:"virtualId"`
+	Player    bool     `json:"player"`
+	Creatures []string `json:"creatures"`
+}
+
+// PromptState holds the constraints of the action prompt a player has to respond to
+type PromptState struct {
+	Text          string   `json:"text"`
+	Cards         []string `json:"cards"`
+	MinSelections int      `json:"minSelections"`
+	MaxSelections int      `json:"maxSelections"`
+	Cancellable   bool     `json:"cancellable"`
+	Suggested     []string `json:"suggested,omitempty"`
+}
@@ -0,0 +1,238 @@
+package server
+
+//go:generate go run ../cmd/protocol -out ../docs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const (
+	// ProtocolVersion is the newest version of the websocket protocol the server speaks
+	ProtocolVersion = 3
+	// MinProtocolVersion is the oldest version of the websocket protocol the server speaks,
+	// clients that do not send a hello message are assumed to speak it
+	MinProtocolVersion = 1
+)
+
+// Direction tells if a message is sent by the client or by the server
+type Direction string
+
+const (
+	// Inbound messages are sent from the client to the server
+	Inbound Direction = "inbound"
+	// Outbound messages are sent from the server to the client
+	Outbound Direction = "outbound"
+)
+
+// Hub names used in the protocol registry
+const (
+	LobbyHub  = "lobby"
+	MatchHub  = "match"
+	ReplayHub = "replay"
+)
+
+// AllHubs lists every hub, messages registered for it are available everywhere
+var AllHubs = []string{LobbyHub, MatchHub, ReplayHub}
+
+// MessageType describes a message of the protocol
+type MessageType struct {
+	Header      string
+	Direction   Direction
+	Since       int
+	Hubs        []string
+	Description string
+	Type        reflect.Type
+}
+
+// Name returns a unique name of the message type, used in the schema and catalog.
+// The struct name is added when more than one message is sent with the same header
+func (t MessageType) Name() string {
+
+	name := fmt.Sprintf("%s.%s", t.Direction, t.Header)
+
+	for _, other := range registry {
+		if other.Direction == t.Direction && other.Header == t.Header && other.Type != t.Type {
+			return fmt.Sprintf("%s.%s", name, t.Type.Name())
+		}
+	}
+
+	return name
+
+}
+
+// InHub returns true if the message is used in the specified hub
+func (t MessageType) InHub(hub string) bool {
+
+	for _, h := range t.Hubs {
+		if h == hub {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+var registry = make([]MessageType, 0)
+
+// registerInbound adds a message the client can send to the registry, v is
+// the struct the message is decoded into
+func registerInbound(header string, since int, hubs []string, v interface{}, description string) {
+	register(MessageType{header, Inbound, since, hubs, description, reflect.TypeOf(v)})
+}
+
+// registerOutbound adds a message the server can send to the registry
+func registerOutbound(header string, since int, hubs []string, v interface{}, description string) {
+	register(MessageType{header, Outbound, since, hubs, description, reflect.TypeOf(v)})
+}
+
+// register adds the message type to the registry. Inbound messages must be unique per hub
+// so they can be decoded, the server can send different messages with the same header
+func register(t MessageType) {
+
+	for _, other := range registry {
+
+		if other.Direction != t.Direction || other.Header != t.Header {
+			continue
+		}
+
+		if t.Direction == Outbound && other.Type != t.Type {
+			continue
+		}
+
+		for _, hub := range t.Hubs {
+			if other.InHub(hub) {
+				panic(fmt.Sprintf("%s message %s is registered twice for the %s hub", t.Direction, t.Header, hub))
+			}
+		}
+
+	}
+
+	registry = append(registry, t)
+
+	sort.SliceStable(registry, func(i, j int) bool {
+
+		if registry[i].Direction != registry[j].Direction {
+			return registry[i].Direction == Inbound
+		}
+
+		return registry[i].Header < registry[j].Header
+
+	})
+
+}
+
+// MessageTypes returns every registered message, inbound messages first and ordered by header
+func MessageTypes() []MessageType {
+	return append([]MessageType{}, registry...)
+}
+
+// Validator is implemented by inbound messages that need more checks than the json types
+type Validator interface {
+	Validate() error
+}
+
+// Request is a decoded and validated message received from a client
+type Request struct {
+	Header  string
+	Message interface{}
+	Data    []byte
+}
+
+// ErrUnknownHeader is returned when decoding a message that is not part of the protocol
+var ErrUnknownHeader = errors.New("Unknown message")
+
+// Decode decodes and validates a message sent to the hub by a client speaking the specified version.
+// Message holds a pointer to the registered struct of the message
+func Decode(hub string, version int, data []byte) (*Request, error) {
+
+	var message Message
+
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, errors.New("Messages must be json objects")
+	}
+
+	var t *MessageType
+
+	for i, other := range registry {
+		if other.Direction == Inbound && other.Header == message.Header {
+			t = &registry[i]
+			if other.InHub(hub) {
+				break
+			}
+		}
+	}
+
+	if t == nil || t.Since > version {
+		return &Request{Header: message.Header, Data: data}, ErrUnknownHeader
+	}
+
+	if !t.InHub(hub) {
+		return &Request{Header: message.Header, Data: data}, fmt.Errorf("%s messages can not be sent to the %s", message.Header, hub)
+	}
+
+	v := reflect.New(t.Type)
+
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+
+		var typeErr *json.UnmarshalTypeError
+
+		if errors.As(err, &typeErr) {
+			err = fmt.Errorf("%s must be of type %s", typeErr.Field, typeErr.Type)
+		}
+
+		return &Request{Header: message.Header, Data: data}, err
+
+	}
+
+	r := &Request{
+		Header:  message.Header,
+		Message: v.Interface(),
+		Data:    data,
+	}
+
+	if validator, ok := r.Message.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return r, err
+		}
+	}
+
+	return r, nil
+
+}
+
+// NegotiateVersion returns the newest version supported by both the server and the client
+func NegotiateVersion(versions []int) (int, error) {
+
+	best := 0
+
+	for _, version := range versions {
+		if version >= MinProtocolVersion && version <= ProtocolVersion && version > best {
+			best = version
+		}
+	}
+
+	if best == 0 {
+		return 0, fmt.Errorf("None of the protocol versions %v are supported, the server speaks versions %v to %v", versions, MinProtocolVersion, ProtocolVersion)
+	}
+
+	return best, nil
+
+}
+
+// SupportedVersions returns the protocol versions the server speaks
+func SupportedVersions() []int {
+
+	versions := make([]int, 0)
+
+	for v := MinProtocolVersion; v <= ProtocolVersion; v++ {
+		versions = append(versions, v)
+	}
+
+	return versions
+
+}
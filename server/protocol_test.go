@@ -0,0 +1,81 @@
+package server_test
+
+import (
+	"duel-masters/server"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+
+	r, err := server.Decode(server.MatchHub, server.ProtocolVersion, []byte(`{"header":"add_to_playzone","virtualId":"abc"}`))
+
+	if err != nil {
+		t.Fatalf("Expected the message to be decoded, got %v", err)
+	}
+
+	if msg, ok := r.Message.(*server.CardRequest); !ok || msg.ID != "abc" {
+		t.Errorf("Expected a card request for abc, got %#v", r.Message)
+	}
+
+	invalid := map[string]string{
+		"not json":              `add_to_playzone`,
+		"unknown header":        `{"header":"summon"}`,
+		"wrong hub":             `{"header":"subscribe"}`,
+		"wrong type":            `{"header":"add_to_playzone","virtualId":5}`,
+		"missing field":         `{"header":"add_to_playzone"}`,
+		"duplicate selection":   `{"header":"action","cards":["a","a"]}`,
+		"empty chat":            `{"header":"chat","message":""}`,
+		"hello without version": `{"header":"hello","versions":[]}`,
+	}
+
+	for name, data := range invalid {
+		if _, err := server.Decode(server.MatchHub, server.ProtocolVersion, []byte(data)); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+
+}
+
+func TestNegotiateVersion(t *testing.T) {
+
+	if v, err := server.NegotiateVersion([]int{server.ProtocolVersion + 1, server.ProtocolVersion}); err != nil || v != server.ProtocolVersion {
+		t.Errorf("Expected version %v to be negotiated, got %v %v", server.ProtocolVersion, v, err)
+	}
+
+	if _, err := server.NegotiateVersion([]int{server.ProtocolVersion + 1}); err == nil {
+		t.Error("Expected unsupported versions to be rejected")
+	}
+
+}
+
+// The generated documentation has to be updated with go generate when the protocol changes
+func TestProtocolDocs(t *testing.T) {
+
+	schema, err := json.MarshalIndent(server.Schema(), "", "  ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := map[string]string{
+		"../docs/protocol.schema.json": string(schema) + "\n",
+		"../docs/protocol.md":          server.Catalog(),
+	}
+
+	for file, expected := range docs {
+
+		data, err := ioutil.ReadFile(file)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != expected {
+			t.Errorf("%s is out of date, run go generate ./server", file)
+		}
+
+	}
+
+}
@@ -0,0 +1,110 @@
+package server
+
+import "errors"
+
+// HelloRequest is sent by the client to negotiate the protocol version
+type HelloRequest struct {
+	Header   string `json:"header"`
+	Versions []int  `json:"versions"`
+}
+
+// Validate returns an error if the client did not list any versions
+func (r *HelloRequest) Validate() error {
+
+	if len(r.Versions) < 1 {
+		return errors.New("versions must list at least one protocol version")
+	}
+
+	return nil
+
+}
+
+// ChatRequest is used to send a chat message
+type ChatRequest struct {
+	Header  string `json:"header"`
+	Message string `json:"message"`
+}
+
+// Validate returns an error if the chat message is empty
+func (r *ChatRequest) Validate() error {
+
+	if len(r.Message) < 1 {
+		return errors.New("message can not be empty")
+	}
+
+	return nil
+
+}
+
+// ChooseDeckRequest is used to choose the deck to play a match with
+type ChooseDeckRequest struct {
+	Header string `json:"header"`
+	UID    string `json:"uid"`
+}
+
+// Validate returns an error if no deck was specified
+func (r *ChooseDeckRequest) Validate() error {
+
+	if r.UID == "" {
+		return errors.New("uid is required")
+	}
+
+	return nil
+
+}
+
+// ChooseFirstRequest is used by the loser of a game in a series to choose who takes the first turn of the next game
+type ChooseFirstRequest struct {
+	Header string `json:"header"`
+	// First is true if the player takes the first turn themselves
+	First bool `json:"first"`
+}
+
+// CardRequest is used for the match moves that are made with a single card
+type CardRequest struct {
+	Header string `json:"header"`
+	ID     string `json:"virtualId"`
+}
+
+// Validate returns an error if no card was specified
+func (r *CardRequest) Validate() error {
+
+	if r.ID == "" {
+		return errors.New("virtualId is required")
+	}
+
+	return nil
+
+}
+
+// ActionRequest is the response to an action prompt, either a selection of cards or a cancellation
+type ActionRequest struct {
+	Header string   `json:"header"`
+	Cards  []string `json:"cards,omitempty"`
+	Cancel bool     `json:"cancel,omitempty"`
+}
+
+// Validate returns an error if the same card was selected more than once
+func (r *ActionRequest) Validate() error {
+
+	selected := make(map[string]bool)
+
+	for _, c := range r.Cards {
+
+		if selected[c] {
+			return errors.New("You cannot select the same card multiple times")
+		}
+
+		selected[c] = true
+
+	}
+
+	return nil
+
+}
+
+// ReplayGotoRequest is used to jump to a step of a replay
+type ReplayGotoRequest struct {
+	Header string `json:"header"`
+	Step   int    `json:"step"`
+}
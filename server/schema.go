@@ -0,0 +1,287 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Schema returns a JSON Schema (draft-07) of the protocol. Every message is a definition
+// named after MessageType.Name, the inbound and outbound definitions list all messages
+// a client can send and receive
+func Schema() map[string]interface{} {
+
+	definitions := make(map[string]interface{})
+
+	inbound := make([]interface{}, 0)
+	outbound := make([]interface{}, 0)
+
+	for _, t := range registry {
+
+		schema := structSchema(t.Type, definitions)
+
+		properties := schema["properties"].(map[string]interface{})
+		properties["header"] = map[string]interface{}{"const": t.Header}
+
+		schema["title"] = t.Header
+		schema["description"] = t.Description
+		schema["x-direction"] = t.Direction
+		schema["x-since"] = t.Since
+		schema["x-hubs"] = t.Hubs
+
+		definitions[t.Name()] = schema
+
+		ref := map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+
+		if t.Direction == Inbound {
+			inbound = append(inbound, ref)
+		} else {
+			outbound = append(outbound, ref)
+		}
+
+	}
+
+	definitions["inbound"] = map[string]interface{}{
+		"description": "Messages sent from the client to the server",
+		"oneOf":       inbound,
+	}
+
+	definitions["outbound"] = map[string]interface{}{
+		"description": "Messages sent from the server to the client",
+		"oneOf":       outbound,
+	}
+
+	return map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "duel-masters websocket protocol",
+		"description": "Messages are json objects identified by their header. The first message sent on a connection is the authorization token as plain text",
+		"x-version":   ProtocolVersion,
+		"x-versions":  SupportedVersions(),
+		"oneOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/inbound"},
+			map[string]interface{}{"$ref": "#/definitions/outbound"},
+		},
+		"definitions": definitions,
+	}
+
+}
+
+// typeSchema returns the schema of a go type as it is encoded by encoding/json,
+// named structs are added to the definitions and referenced
+func typeSchema(t reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+
+	switch t.Kind() {
+
+	case reflect.Ptr:
+		return typeSchema(t.Elem(), definitions)
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+
+	case reflect.Slice, reflect.Array:
+		// nil slices are encoded as null
+		return map[string]interface{}{
+			"type":  []string{"array", "null"},
+			"items": typeSchema(t.Elem(), definitions),
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 []string{"object", "null"},
+			"additionalProperties": typeSchema(t.Elem(), definitions),
+		}
+
+	case reflect.Struct:
+
+		if t.Name() == "" {
+			return structSchema(t, definitions)
+		}
+
+		if _, ok := definitions[t.Name()]; !ok {
+			// reserve the name before recursing in case the struct references itself
+			definitions[t.Name()] = nil
+			definitions[t.Name()] = structSchema(t, definitions)
+		}
+
+		return map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+
+	}
+
+	return map[string]interface{}{}
+
+}
+
+// structSchema returns the object schema of a struct, fields without omitempty are required
+func structSchema(t reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+
+		if tag == "-" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		name := options[0]
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = typeSchema(field.Type, definitions)
+
+		omitempty := false
+
+		for _, option := range options[1:] {
+			if option == "omitempty" {
+				omitempty = true
+			}
+		}
+
+		if !omitempty {
+			required = append(required, name)
+		}
+
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+}
+
+// Catalog returns a markdown document that lists every message of the protocol and its fields
+func Catalog() string {
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Websocket protocol\n\n")
+	fmt.Fprintf(&b, "<!-- Generated by `go generate ./server`, do not edit -->\n\n")
+	fmt.Fprintf(&b, "Protocol version %v, the server speaks versions %v. ", ProtocolVersion, SupportedVersions())
+	fmt.Fprintf(&b, "The JSON Schema of the protocol is in [protocol.schema.json](protocol.schema.json) and is served at `/api/protocol`.\n\n")
+	fmt.Fprintf(&b, "A connection is opened on `/ws/lobby`, `/ws/<match id>` or `/ws/replay-<replay id>`. ")
+	fmt.Fprintf(&b, "The first message must be the authorization token as plain text, the server then sends `hello` with the versions it speaks. ")
+	fmt.Fprintf(&b, "The client answers with `hello` and the versions it speaks, and the server replies with the negotiated `version`. ")
+	fmt.Fprintf(&b, "Clients that skip the handshake speak version %v. ", MinProtocolVersion)
+	fmt.Fprintf(&b, "Messages that can not be decoded or are invalid are answered with `protocol_error`.\n")
+
+	sections := []struct {
+		title     string
+		direction Direction
+	}{
+		{"Client to server", Inbound},
+		{"Server to client", Outbound},
+	}
+
+	for _, section := range sections {
+
+		fmt.Fprintf(&b, "\n## %s\n", section.title)
+
+		for _, t := range registry {
+
+			if t.Direction != section.direction {
+				continue
+			}
+
+			if t.Name() == fmt.Sprintf("%s.%s", t.Direction, t.Header) {
+				fmt.Fprintf(&b, "\n### %s\n\n", t.Header)
+			} else {
+				fmt.Fprintf(&b, "\n### %s (%s)\n\n", t.Header, t.Type.Name())
+			}
+
+			fmt.Fprintf(&b, "%s.\n\n", t.Description)
+			fmt.Fprintf(&b, "Hubs: %s. Since version %v.\n", strings.Join(t.Hubs, ", "), t.Since)
+
+			schema := structSchema(t.Type, make(map[string]interface{}))
+			fields := make([]string, 0)
+
+			for i := 0; i < t.Type.NumField(); i++ {
+
+				field := t.Type.Field(i)
+				name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+				if name == "header" || name == "-" || field.PkgPath != "" {
+					continue
+				}
+
+				required := "no"
+
+				for _, r := range schema["required"].([]string) {
+					if r == name {
+						required = "yes"
+					}
+				}
+
+				fields = append(fields, fmt.Sprintf("| `%s` | `%s` | %s |", name, typeName(field.Type), required))
+
+			}
+
+			if len(fields) > 0 {
+				fmt.Fprintf(&b, "\n| Field | Type | Required |\n| --- | --- | --- |\n%s\n", strings.Join(fields, "\n"))
+			}
+
+		}
+
+	}
+
+	return b.String()
+
+}
+
+// typeName returns a short json type name for the catalog
+func typeName(t reflect.Type) string {
+
+	switch t.Kind() {
+
+	case reflect.Ptr:
+		return typeName(t.Elem())
+
+	case reflect.String:
+		return "string"
+
+	case reflect.Bool:
+		return "boolean"
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+
+	case reflect.Float32, reflect.Float64:
+		return "number"
+
+	case reflect.Slice, reflect.Array:
+		return typeName(t.Elem()) + "[]"
+
+	case reflect.Map:
+		return "{[" + typeName(t.Key()) + "]: " + typeName(t.Elem()) + "}"
+
+	case reflect.Struct:
+		return t.Name()
+
+	}
+
+	return "any"
+
+}
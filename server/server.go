@@ -1,8 +1,9 @@
 package server
 
-// Hub is an interface that accepts incoming websocket messages
+// Hub is an interface that accepts incoming websocket messages, the messages
+// are decoded and validated against the protocol before they are passed on
 type Hub interface {
-	Parse(s *Socket, data []byte)
+	Parse(s *Socket, r *Request)
 	Name() string
 	OnSocketClose(s *Socket)
 }
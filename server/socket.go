@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"duel-masters/db"
+	"duel-masters/metrics"
 
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
@@ -20,6 +21,31 @@ const (
 var sockets = make(map[*Socket]Hub)
 var socketsMutex = sync.Mutex{}
 
+var sendErrors = metrics.NewCounter("duelmasters_socket_send_errors_total", "Messages that could not be written to a websocket, by hub", "hub")
+
+func init() {
+
+	metrics.NewGaugeFunc("duelmasters_sockets", "Open websocket connections, by hub", "hub", func() map[string]float64 {
+
+		result := make(map[string]float64)
+
+		for _, hub := range AllHubs {
+			result[hub] = 0
+		}
+
+		socketsMutex.Lock()
+		defer socketsMutex.Unlock()
+
+		for _, hub := range sockets {
+			result[hub.Name()]++
+		}
+
+		return result
+
+	})
+
+}
+
 // Sockets returns a list of the current sockets
 func Sockets() []*Socket {
 	result := make([]*Socket, 0)
@@ -40,18 +66,21 @@ type Socket struct {
 	mutex  *sync.Mutex
 	closed bool
 	lost   bool
+	// Version is the protocol version negotiated with the client
+	Version int
 }
 
 // NewSocket creates and returns a new Socket instance
 func NewSocket(c *websocket.Conn, hub Hub) *Socket {
 
 	s := &Socket{
-		conn:   c,
-		hub:    hub,
-		ready:  false,
-		mutex:  &sync.Mutex{},
-		closed: false,
-		lost:   false,
+		conn:    c,
+		hub:     hub,
+		ready:   false,
+		mutex:   &sync.Mutex{},
+		closed:  false,
+		lost:    false,
+		Version: MinProtocolVersion,
 	}
 
 	socketsMutex.Lock()
@@ -100,16 +129,76 @@ func (s *Socket) Listen() {
 			s.User = u
 			s.ready = true
 
-			s.Send(Message{Header: "hello"})
+			s.Send(HelloMessage{
+				Header:   "hello",
+				Versions: SupportedVersions(),
+			})
 
 			continue
 
 		}
 
-		go s.hub.Parse(s, message)
+		r, err := Decode(s.hub.Name(), s.Version, message)
+
+		if err != nil {
+
+			header := ""
+
+			if r != nil {
+				header = r.Header
+			}
+
+			s.ProtocolError(header, err)
+
+			continue
 
+		}
+
+		// The version is negotiated before any following messages are parsed
+		if r.Header == "hello" {
+			s.negotiate(r.Message.(*HelloRequest))
+			continue
+		}
+
+		// Messages are parsed one at a time so they are handled in the order they were sent
+		s.hub.Parse(s, r)
+
+	}
+
+}
+
+// negotiate picks the protocol version for the socket, the connection is closed
+// if the client does not speak any of the supported versions
+func (s *Socket) negotiate(hello *HelloRequest) {
+
+	version, err := NegotiateVersion(hello.Versions)
+
+	if err != nil {
+		s.ProtocolError(hello.Header, err)
+		s.Close()
+		return
 	}
 
+	s.Version = version
+
+	s.Send(VersionMessage{
+		Header:  "version",
+		Version: version,
+	})
+
+}
+
+// ProtocolError tells the client that the message with the specified header was not accepted
+func (s *Socket) ProtocolError(header string, err error) {
+
+	logrus.Debugf("Rejected %s message from %s. %v", header, s.User.Username, err)
+
+	s.Send(ProtocolErrorMessage{
+		Header:  "protocol_error",
+		Request: header,
+		Message: err.Error(),
+	})
+
 }
 
 func (s *Socket) handlePing() {
@@ -155,6 +244,7 @@ func (s *Socket) Send(v interface{}) {
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Warnf("Recovered from panic in socket Send. %v", r)
+			metrics.Panics.Inc("socket_send")
 			return
 		}
 	}()
@@ -163,6 +253,7 @@ func (s *Socket) Send(v interface{}) {
 	s.conn.SetWriteDeadline(time.Now().Add(writeWait))
 	if err := s.conn.WriteJSON(v); err != nil {
 		logrus.Debug(err)
+		sendErrors.Inc(s.hub.Name())
 	}
 	s.mutex.Unlock()
 
@@ -174,6 +265,7 @@ func (s *Socket) Close() {
 	defer func() {
 		if r := recover(); r != nil {
 			logrus.Warnf("Recovered from socket close. %v", r)
+			metrics.Panics.Inc("socket_close")
 			return
 		}
 	}()
@@ -215,6 +307,7 @@ func GetUserList() UserListMessage {
 			Color:       s.User.Color,
 			Hub:         h.Name(),
 			Permissions: s.User.Permissions,
+			Bot:         s.User.Bot,
 		}
 
 		if _, ok := usersMap[s.User.Username]; ok {
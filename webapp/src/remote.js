@@ -3,6 +3,9 @@ import config from "./config";
 
 export const ws_protocol = location.protocol == "https:" ? "wss://" : "ws://";
 
+// The websocket protocol version this client speaks, see docs/protocol.md
+export const protocolVersion = 3;
+
 export const call = (opts) => {
 
   return new Promise((resolve, reject) => {